@@ -0,0 +1,348 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	defaultCalibrationBuckets = 10
+	// minProbability bounds log loss for outcomes predicted as impossible.
+	minProbability = 1e-15
+	// defaultDrawRate is the draw probability EloPredictor gives evenly matched sides.
+	defaultDrawRate = 0.28
+	// defaultRefitInterval is how often DixonColesPredictor refits its model.
+	defaultRefitInterval = 7 * 24 * time.Hour
+)
+
+// Match results as reported in BacktestPrediction.
+const (
+	ResultHomeWin = "H"
+	ResultDraw    = "D"
+	ResultAwayWin = "A"
+)
+
+// OutcomeProbabilities are the probabilities of a home win, a draw and an away win.
+type OutcomeProbabilities struct {
+	HomeWin float64 `json:"home_win" bson:"home_win"`
+	Draw    float64 `json:"draw" bson:"draw"`
+	AwayWin float64 `json:"away_win" bson:"away_win"`
+}
+
+// Predictor estimates the outcome of a fixture. history holds the played
+// fixtures that kicked off before it, oldest first, and nothing later.
+// Predictors may return a NotFound error for teams they know nothing about.
+type Predictor interface {
+	Predict(fixture GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error)
+}
+
+// PredictorFunc adapts a function to the Predictor interface.
+type PredictorFunc func(fixture GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error)
+
+// Predict calls fn.
+func (fn PredictorFunc) Predict(fixture GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error) {
+	return fn(fixture, history)
+}
+
+// Backtest evaluates a Predictor on played fixtures in kickoff order.
+type Backtest struct {
+	Predictor Predictor
+	// From and To bound the fixtures evaluated, To excluded; zero bounds are
+	// open. Earlier fixtures still make up the history.
+	From time.Time
+	To   time.Time
+	// Buckets is the number of equal-width calibration buckets.
+	Buckets int
+}
+
+// BacktestReport holds the mean scores of a backtest, lower being better for
+// each, its calibration and the individual predictions.
+type BacktestReport struct {
+	Matches     int                  `json:"matches" bson:"matches"`
+	Skipped     int                  `json:"skipped" bson:"skipped"`
+	Brier       float64              `json:"brier" bson:"brier"`
+	LogLoss     float64              `json:"log_loss" bson:"log_loss"`
+	RPS         float64              `json:"rps" bson:"rps"`
+	Calibration []CalibrationBucket  `json:"calibration" bson:"calibration"`
+	Predictions []BacktestPrediction `json:"predictions" bson:"predictions"`
+}
+
+// BacktestPrediction is a single evaluated prediction and its scores.
+type BacktestPrediction struct {
+	FixtureID     string               `json:"fixture_id" bson:"fixture_id"`
+	Date          time.Time            `json:"date" bson:"date"`
+	Probabilities OutcomeProbabilities `json:"probabilities" bson:"probabilities"`
+	Outcome       string               `json:"outcome" bson:"outcome"`
+	Brier         float64              `json:"brier" bson:"brier"`
+	LogLoss       float64              `json:"log_loss" bson:"log_loss"`
+	RPS           float64              `json:"rps" bson:"rps"`
+}
+
+// CalibrationBucket compares the mean predicted probability of the outcomes
+// predicted within [Lower, Upper) with how often they happened.
+type CalibrationBucket struct {
+	Lower     float64 `json:"lower" bson:"lower"`
+	Upper     float64 `json:"upper" bson:"upper"`
+	Count     int     `json:"count" bson:"count"`
+	Predicted float64 `json:"predicted" bson:"predicted"`
+	Observed  float64 `json:"observed" bson:"observed"`
+}
+
+// Run walks the played fixtures chronologically, asking the predictor about
+// each one in range with only the fixtures that kicked off before it. Fixtures
+// the predictor reports NotFound for are skipped; any other error stops the run.
+func (b *Backtest) Run(ctx context.Context, fixtures []GeneralFixtureData) (BacktestReport, error) {
+	var played []GeneralFixtureData
+	for _, g := range LatestFixtures(fixtures) {
+		if g.FixtureData.Outcome().CountsInStatistics() {
+			played = append(played, g)
+		}
+	}
+	sortFixtures(played)
+
+	buckets := b.Buckets
+	if buckets <= 0 {
+		buckets = defaultCalibrationBuckets
+	}
+	calibration := newCalibration(buckets)
+
+	var report BacktestReport
+	prior := 0
+	for _, g := range played {
+		if err := ctx.Err(); err != nil {
+			return BacktestReport{}, err
+		}
+		f := g.FixtureData
+		for prior < len(played) && played[prior].FixtureData.Date.Before(f.Date) {
+			prior++
+		}
+		if (!b.From.IsZero() && f.Date.Before(b.From)) || (!b.To.IsZero() && !f.Date.Before(b.To)) {
+			continue
+		}
+
+		probs, err := b.Predictor.Predict(g, played[:prior:prior])
+		switch {
+		case errors.Is(err, ErrNotFound):
+			report.Skipped++
+			continue
+		case err != nil:
+			return BacktestReport{}, fmt.Errorf("predict fixture %s: %w", g.FixtureID, err)
+		}
+		probs, err = probs.normalized()
+		if err != nil {
+			return BacktestReport{}, fmt.Errorf("predict fixture %s: %w", g.FixtureID, err)
+		}
+
+		p := scorePrediction(g, probs)
+		calibration.add(probs, p.Outcome)
+		report.Predictions = append(report.Predictions, p)
+		report.Brier += p.Brier
+		report.LogLoss += p.LogLoss
+		report.RPS += p.RPS
+	}
+
+	report.Matches = len(report.Predictions)
+	if report.Matches > 0 {
+		n := float64(report.Matches)
+		report.Brier /= n
+		report.LogLoss /= n
+		report.RPS /= n
+	}
+	report.Calibration = calibration.buckets()
+	return report, nil
+}
+
+// normalized scales the probabilities to sum to one.
+func (p OutcomeProbabilities) normalized() (OutcomeProbabilities, error) {
+	sum := p.HomeWin + p.Draw + p.AwayWin
+	if p.HomeWin < 0 || p.Draw < 0 || p.AwayWin < 0 || sum <= 0 || math.IsNaN(sum) {
+		return OutcomeProbabilities{}, fmt.Errorf("invalid probabilities %+v", p)
+	}
+	return OutcomeProbabilities{HomeWin: p.HomeWin / sum, Draw: p.Draw / sum, AwayWin: p.AwayWin / sum}, nil
+}
+
+// ordered returns the probabilities and the outcome indicator in H, D, A order.
+func (p OutcomeProbabilities) ordered(outcome string) ([3]float64, [3]float64) {
+	probs := [3]float64{p.HomeWin, p.Draw, p.AwayWin}
+	var observed [3]float64
+	switch outcome {
+	case ResultHomeWin:
+		observed[0] = 1
+	case ResultDraw:
+		observed[1] = 1
+	case ResultAwayWin:
+		observed[2] = 1
+	}
+	return probs, observed
+}
+
+func scorePrediction(g GeneralFixtureData, probs OutcomeProbabilities) BacktestPrediction {
+	f := g.FixtureData
+	p := BacktestPrediction{FixtureID: g.FixtureID, Date: f.Date, Probabilities: probs, Outcome: ResultDraw}
+	switch {
+	case f.GoalsHome > f.GoalsAway:
+		p.Outcome = ResultHomeWin
+	case f.GoalsHome < f.GoalsAway:
+		p.Outcome = ResultAwayWin
+	}
+
+	predicted, observed := probs.ordered(p.Outcome)
+	var cumulative float64
+	for i := range predicted {
+		d := predicted[i] - observed[i]
+		p.Brier += d * d
+		if observed[i] == 1 {
+			p.LogLoss = -math.Log(math.Max(predicted[i], minProbability))
+		}
+		if i < len(predicted)-1 {
+			cumulative += d
+			p.RPS += cumulative * cumulative
+		}
+	}
+	p.RPS /= float64(len(predicted) - 1)
+	return p
+}
+
+// calibration accumulates predicted probabilities and outcomes per bucket.
+type calibration struct {
+	count     []int
+	predicted []float64
+	observed  []float64
+}
+
+func newCalibration(buckets int) *calibration {
+	return &calibration{
+		count:     make([]int, buckets),
+		predicted: make([]float64, buckets),
+		observed:  make([]float64, buckets),
+	}
+}
+
+func (c *calibration) add(probs OutcomeProbabilities, outcome string) {
+	predicted, observed := probs.ordered(outcome)
+	n := len(c.count)
+	for i, p := range predicted {
+		b := min(int(p*float64(n)), n-1)
+		c.count[b]++
+		c.predicted[b] += p
+		c.observed[b] += observed[i]
+	}
+}
+
+func (c *calibration) buckets() []CalibrationBucket {
+	n := len(c.count)
+	out := make([]CalibrationBucket, n)
+	for b := range out {
+		out[b] = CalibrationBucket{Lower: float64(b) / float64(n), Upper: float64(b+1) / float64(n), Count: c.count[b]}
+		if c.count[b] > 0 {
+			out[b].Predicted = c.predicted[b] / float64(c.count[b])
+			out[b].Observed = c.observed[b] / float64(c.count[b])
+		}
+	}
+	return out
+}
+
+// EloPredictor predicts from Elo ratings over the history, splitting the
+// home side's expected score into win, draw and loss. It rates history
+// incrementally, so it must be given growing histories of the same fixtures,
+// as a Backtest does.
+type EloPredictor struct {
+	Config EloConfig
+	// DrawRate is the draw probability of evenly matched sides, shrinking as
+	// the expected score moves away from one half.
+	DrawRate float64
+
+	ratings *EloRatings
+	seen    int
+}
+
+// NewEloPredictor returns an EloPredictor using cfg and the default draw rate.
+func NewEloPredictor(cfg EloConfig) *EloPredictor {
+	return &EloPredictor{Config: cfg, DrawRate: defaultDrawRate}
+}
+
+// Predict returns the outcome probabilities of the fixture.
+func (p *EloPredictor) Predict(fixture GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error) {
+	if p.ratings == nil || len(history) < p.seen {
+		p.ratings, p.seen = NewEloRatings(p.Config), 0
+	}
+	for _, g := range history[p.seen:] {
+		p.ratings.Update(g)
+	}
+	p.seen = len(history)
+
+	expected := p.ratings.PreMatch(fixture).HomeExpected
+	draw := p.DrawRate * (1 - math.Abs(2*expected-1))
+	return OutcomeProbabilities{
+		HomeWin: math.Max(expected-draw/2, 0),
+		Draw:    draw,
+		AwayWin: math.Max(1-expected-draw/2, 0),
+	}, nil
+}
+
+// DixonColesPredictor predicts from a Dixon-Coles model fitted on the history,
+// refitting once the fixture is RefitInterval past the last fit.
+type DixonColesPredictor struct {
+	Fitter        *DixonColesFitter
+	RefitInterval time.Duration
+
+	model *DixonColesModel
+}
+
+// NewDixonColesPredictor returns a DixonColesPredictor refitting weekly with
+// fitter, or with NewDixonColesFitter when nil.
+func NewDixonColesPredictor(fitter *DixonColesFitter) *DixonColesPredictor {
+	return &DixonColesPredictor{Fitter: fitter, RefitInterval: defaultRefitInterval}
+}
+
+// Predict returns the outcome probabilities of the fixture. It reports
+// NotFound until both teams have played in the history.
+func (p *DixonColesPredictor) Predict(fixture GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error) {
+	f := fixture.FixtureData
+	if p.model == nil || f.Date.Before(p.model.FittedAt) || f.Date.Sub(p.model.FittedAt) >= p.RefitInterval {
+		if len(history) == 0 {
+			return OutcomeProbabilities{}, NotFound("fixture history", fixture.FixtureID)
+		}
+		fitter := NewDixonColesFitter()
+		if p.Fitter != nil {
+			*fitter = *p.Fitter
+		}
+		fitter.At = f.Date
+		model, err := fitter.Fit(history)
+		if err != nil {
+			return OutcomeProbabilities{}, err
+		}
+		p.model = model
+	}
+	probs, err := p.model.Predict(f)
+	if err != nil {
+		return OutcomeProbabilities{}, err
+	}
+	return OutcomeProbabilities{HomeWin: probs.HomeWin, Draw: probs.Draw, AwayWin: probs.AwayWin}, nil
+}
+
+// PoissonPredictor predicts with a PoissonModel from team statistics and
+// league averages computed over the history, ignoring the statistics stored on
+// the fixture, which may include later matches.
+type PoissonPredictor struct{}
+
+// Predict returns the outcome probabilities of the fixture. It reports
+// NotFound until both teams have played in the history.
+func (PoissonPredictor) Predict(fixture GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error) {
+	f := fixture.FixtureData
+	home := ComputeTeamStatistics(history, f.HomeTeamID)
+	away := ComputeTeamStatistics(history, f.AwayTeamID)
+	if home.PlayedHome == 0 || away.PlayedAway == 0 {
+		return OutcomeProbabilities{}, NotFound("team statistics", fixture.FixtureID)
+	}
+	model := NewPoissonModel(LeagueAveragesFromFixtures(history))
+	fixture.HomeTeamStats, fixture.AwayTeamStats = home, away
+	probs, err := model.Predict(fixture)
+	if err != nil {
+		return OutcomeProbabilities{}, err
+	}
+	return OutcomeProbabilities{HomeWin: probs.HomeWin, Draw: probs.Draw, AwayWin: probs.AwayWin}, nil
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestScorePrediction(t *testing.T) {
+	probs := OutcomeProbabilities{HomeWin: 0.5, Draw: 0.3, AwayWin: 0.2}
+	tests := []struct {
+		name                 string
+		goalsHome, goalsAway int
+		probs                OutcomeProbabilities
+		outcome              string
+		brier, logLoss, rps  float64
+	}{
+		// (0.5-1)² + 0.3² + 0.2²; RPS ((-0.5)² + (-0.2)²) / 2.
+		{"home win", 2, 1, probs, ResultHomeWin, 0.38, 0.693147, 0.145},
+		{"draw", 1, 1, probs, ResultDraw, 0.78, 1.203973, 0.145},
+		{"away win", 0, 1, probs, ResultAwayWin, 0.98, 1.609438, 0.445},
+		// A certain home win that ends away is capped at -ln(1e-15).
+		{"impossible outcome", 0, 3, OutcomeProbabilities{HomeWin: 1}, ResultAwayWin, 2, 34.538776, 1},
+	}
+	for _, tt := range tests {
+		g := meeting(1, 2, tt.goalsHome, tt.goalsAway)
+		p := scorePrediction(g, tt.probs)
+		if p.Outcome != tt.outcome || math.Abs(p.Brier-tt.brier) > 1e-6 || math.Abs(p.LogLoss-tt.logLoss) > 1e-6 || math.Abs(p.RPS-tt.rps) > 1e-6 {
+			t.Errorf("%s: %s brier %v log loss %v rps %v, want %s %v %v %v",
+				tt.name, p.Outcome, p.Brier, p.LogLoss, p.RPS, tt.outcome, tt.brier, tt.logLoss, tt.rps)
+		}
+	}
+}
+
+func TestOutcomeProbabilitiesNormalized(t *testing.T) {
+	got, err := OutcomeProbabilities{HomeWin: 2, Draw: 1, AwayWin: 1}.normalized()
+	if err != nil || got != (OutcomeProbabilities{HomeWin: 0.5, Draw: 0.25, AwayWin: 0.25}) {
+		t.Fatalf("normalized = %+v, %v", got, err)
+	}
+	for _, p := range []OutcomeProbabilities{{}, {HomeWin: -0.1, Draw: 1}, {HomeWin: math.NaN()}} {
+		if _, err := p.normalized(); err == nil {
+			t.Errorf("normalized(%+v) accepted", p)
+		}
+	}
+}
+
+func backtestFixtures() []GeneralFixtureData {
+	fixtures := []GeneralFixtureData{meeting(1, 2, 2, 1), meeting(3, 4, 1, 1), meeting(2, 3, 0, 1)}
+	for i := range fixtures {
+		fixtures[i].FixtureData.Date = time.Date(2024, 1, i+1, 15, 0, 0, 0, time.UTC)
+	}
+	return fixtures
+}
+
+func TestBacktestRun(t *testing.T) {
+	var histories []int
+	constant := PredictorFunc(func(f GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error) {
+		for _, g := range history {
+			if !g.FixtureData.Date.Before(f.FixtureData.Date) {
+				t.Fatalf("fixture %s sees %s in its history", f.FixtureID, g.FixtureID)
+			}
+		}
+		histories = append(histories, len(history))
+		return OutcomeProbabilities{HomeWin: 5, Draw: 3, AwayWin: 2}, nil
+	})
+	report, err := (&Backtest{Predictor: constant}).Run(context.Background(), backtestFixtures())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The means of the home win, draw and away win rows of TestScorePrediction.
+	if report.Matches != 3 || math.Abs(report.Brier-0.713333) > 1e-6 || math.Abs(report.LogLoss-1.168853) > 1e-6 || math.Abs(report.RPS-0.245) > 1e-9 {
+		t.Fatalf("report = %d matches, brier %v, log loss %v, rps %v", report.Matches, report.Brier, report.LogLoss, report.RPS)
+	}
+	if len(histories) != 3 || histories[0] != 0 || histories[2] != 2 {
+		t.Fatalf("history lengths = %v", histories)
+	}
+	// Each outcome at 0.5 lands in bucket 5 and happened once in three.
+	if b := report.Calibration[5]; b.Count != 3 || math.Abs(b.Predicted-0.5) > 1e-9 || math.Abs(b.Observed-1.0/3) > 1e-9 {
+		t.Fatalf("bucket 5 = %+v", b)
+	}
+	if b := report.Calibration[2]; b.Count != 3 || math.Abs(b.Observed-1.0/3) > 1e-9 {
+		t.Fatalf("bucket 2 = %+v", b)
+	}
+}
+
+func TestBacktestRunSkipsAndBounds(t *testing.T) {
+	fixtures := backtestFixtures()
+	known := PredictorFunc(func(f GeneralFixtureData, history []GeneralFixtureData) (OutcomeProbabilities, error) {
+		if len(history) == 0 {
+			return OutcomeProbabilities{}, NotFound("team statistics", f.FixtureID)
+		}
+		return OutcomeProbabilities{HomeWin: 1, Draw: 1, AwayWin: 1}, nil
+	})
+	report, err := (&Backtest{Predictor: known}).Run(context.Background(), fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Uniform predictions score 2/3 Brier and ln 3 log loss whatever happens.
+	if report.Matches != 2 || report.Skipped != 1 || math.Abs(report.Brier-2.0/3) > 1e-9 || math.Abs(report.LogLoss-math.Log(3)) > 1e-9 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	bounded := &Backtest{Predictor: known, From: fixtures[1].FixtureData.Date, To: fixtures[2].FixtureData.Date}
+	if report, err := bounded.Run(context.Background(), fixtures); err != nil || report.Matches != 1 || report.Predictions[0].FixtureID != fixtures[1].FixtureID {
+		t.Fatalf("bounded report = %+v, %v", report, err)
+	}
+
+	invalid := PredictorFunc(func(GeneralFixtureData, []GeneralFixtureData) (OutcomeProbabilities, error) {
+		return OutcomeProbabilities{}, nil
+	})
+	if _, err := (&Backtest{Predictor: invalid}).Run(context.Background(), fixtures); err == nil {
+		t.Fatal("zero probabilities accepted")
+	}
+	failing := PredictorFunc(func(GeneralFixtureData, []GeneralFixtureData) (OutcomeProbabilities, error) {
+		return OutcomeProbabilities{}, ErrQuotaExhausted
+	})
+	if _, err := (&Backtest{Predictor: failing}).Run(context.Background(), fixtures); !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("predictor error = %v, want it wrapped", err)
+	}
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBatchLimit = 50
+	maxBatchLimit     = 200
+	maxBatchIDs       = 100
+)
+
+// BatchFixtureRepository is the repository a batch request is executed against.
+type BatchFixtureRepository interface {
+	FixtureRepository
+	FixtureFinder
+}
+
+// Validate checks that the request selects fixtures by id or by at least one
+// filter and that its dates, limit and cursor are well formed.
+func (r BatchFixturesRequest) Validate() error {
+	var fields []FieldError
+	if len(r.FixtureIDs) == 0 && len(r.Leagues) == 0 && r.DateFrom == "" && r.DateTo == "" && len(r.TeamIDs) == 0 {
+		fields = append(fields, FieldError{Field: "fixture_ids", Message: "fixture ids, leagues, dates or teams are required"})
+	}
+	if len(r.FixtureIDs) > maxBatchIDs {
+		fields = append(fields, FieldError{Field: "fixture_ids", Message: fmt.Sprintf("at most %d ids are allowed", maxBatchIDs)})
+	}
+	from, fromErr := parseBatchDate(r.DateFrom)
+	if fromErr != nil {
+		fields = append(fields, FieldError{Field: "date_from", Message: "must be formatted as YYYY-MM-DD"})
+	}
+	to, toErr := parseBatchDate(r.DateTo)
+	if toErr != nil {
+		fields = append(fields, FieldError{Field: "date_to", Message: "must be formatted as YYYY-MM-DD"})
+	}
+	if fromErr == nil && toErr == nil && !from.IsZero() && !to.IsZero() && to.Before(from) {
+		fields = append(fields, FieldError{Field: "date_to", Message: "must not be before date_from"})
+	}
+	fields = requireTimezone(fields, "timezone", r.Timezone)
+	if r.Limit < 0 || r.Limit > maxBatchLimit {
+		fields = append(fields, FieldError{Field: "limit", Message: fmt.Sprintf("must be between 0 and %d", maxBatchLimit)})
+	}
+	if _, _, err := decodeBatchCursor(r.Cursor); err != nil {
+		fields = append(fields, FieldError{Field: "cursor", Message: "is malformed"})
+	}
+	return invalidIfAny(fields)
+}
+
+// Filter returns the FixtureFilter expressed by the request. Dates are whole
+// days in the request's timezone, DateTo included.
+func (r BatchFixturesRequest) Filter() FixtureFilter {
+	loc, err := ResolveLocation(r.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	filter := FixtureFilter{LeagueIDs: r.Leagues, TeamIDs: r.TeamIDs, Statuses: r.Statuses}
+	if r.DateFrom != "" {
+		filter.From, _, _ = DayRange(r.DateFrom, loc)
+	}
+	if r.DateTo != "" {
+		_, filter.To, _ = DayRange(r.DateTo, loc)
+	}
+	return filter
+}
+
+// ExecuteBatchFixtures runs a batch request against repo and returns one page
+// of fixtures ordered by kickoff date and fixture id. Filtered requests pass
+// the cursor and page size to FindFixtures. When fixture ids are given, each
+// is looked up and filtered by the remaining criteria, and ids that are
+// missing or fail to load are reported per item on the first page instead of
+// failing the whole batch.
+func ExecuteBatchFixtures(ctx context.Context, repo BatchFixtureRepository, req BatchFixturesRequest) (BatchFixturesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return BatchFixturesResponse{}, err
+	}
+	filter := req.Filter()
+	filter.AfterDate, filter.AfterID, _ = decodeBatchCursor(req.Cursor)
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultBatchLimit
+	}
+
+	var (
+		resp     BatchFixturesResponse
+		fixtures []GeneralFixtureData
+	)
+	if len(req.FixtureIDs) > 0 {
+		seen := make(map[string]bool, len(req.FixtureIDs))
+		for _, id := range req.FixtureIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			f, ok, err := repo.GetFixture(ctx, id)
+			switch {
+			case err != nil:
+				resp.Errors = append(resp.Errors, BatchItemError{FixtureID: id, Error: AsError(err).Body()})
+			case !ok:
+				resp.Errors = append(resp.Errors, BatchItemError{FixtureID: id, Error: NotFound("fixture", id).Body()})
+			case filter.Match(f):
+				fixtures = append(fixtures, f)
+			}
+		}
+		if req.Cursor != "" {
+			resp.Errors = nil
+		}
+		sortFixtures(fixtures)
+	} else {
+		// One extra fixture tells whether there is a next page.
+		filter.Limit = limit + 1
+		found, err := repo.FindFixtures(ctx, filter)
+		if err != nil {
+			return BatchFixturesResponse{}, err
+		}
+		fixtures = found
+	}
+
+	if len(fixtures) > limit {
+		last := fixtures[limit-1]
+		resp.NextCursor = encodeBatchCursor(last.FixtureData.Date, last.FixtureID)
+		fixtures = fixtures[:limit]
+	}
+	resp.Fixtures = fixtures
+	return resp, nil
+}
+
+// sortFixtures orders fixtures by kickoff date and then fixture id.
+func sortFixtures(fixtures []GeneralFixtureData) {
+	sort.SliceStable(fixtures, func(i, j int) bool {
+		return fixtureAfter(fixtures[j], fixtures[i].FixtureData.Date, fixtures[i].FixtureID)
+	})
+}
+
+// fixtureAfter reports whether f sorts strictly after the (date, id) key.
+func fixtureAfter(f GeneralFixtureData, date time.Time, id string) bool {
+	d := f.FixtureData.Date
+	if d.Equal(date) {
+		return f.FixtureID > id
+	}
+	return d.After(date)
+}
+
+func parseBatchDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(fixtureDateLayout, s)
+}
+
+// encodeBatchCursor returns an opaque cursor pointing after the given fixture.
+func encodeBatchCursor(date time.Time, fixtureID string) string {
+	raw := date.UTC().Format(time.RFC3339Nano) + "|" + fixtureID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeBatchCursor(cursor string) (time.Time, string, error) {
+	if cursor == "" {
+		return time.Time{}, "", nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	date, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("malformed cursor")
+	}
+	t, err := time.Parse(time.RFC3339Nano, date)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return t, id, nil
+}
@@ -0,0 +1,29 @@
+package client
+
+// BatchFixturesRequest represents the request body for fetching many fixtures at once,
+// either by id or by a combination of leagues, date range, teams and statuses.
+type BatchFixturesRequest struct {
+	FixtureIDs []string `json:"fixture_ids"`
+	Leagues    []string `json:"leagues"`
+	DateFrom   string   `json:"date_from"`
+	DateTo     string   `json:"date_to"`
+	Timezone   string   `json:"timezone,omitempty"`
+	TeamIDs    []int    `json:"team_ids"`
+	Statuses   []string `json:"statuses"`
+	Cursor     string   `json:"cursor"`
+	Limit      int      `json:"limit"`
+}
+
+// BatchFixturesResponse represents a page of fixtures returned for a BatchFixturesRequest.
+// Errors lists the requested fixture ids that could not be returned.
+type BatchFixturesResponse struct {
+	Fixtures   []GeneralFixtureData `json:"fixtures"`
+	Errors     []BatchItemError     `json:"errors,omitempty"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// BatchItemError describes why a single item of a batch request failed.
+type BatchItemError struct {
+	FixtureID string    `json:"fixture_id"`
+	Error     ErrorBody `json:"error"`
+}
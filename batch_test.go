@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// recordingRepository records the filters FindFixtures is called with.
+type recordingRepository struct {
+	*MemoryFixtureRepository
+	filters []FixtureFilter
+}
+
+func (r *recordingRepository) FindFixtures(ctx context.Context, filter FixtureFilter) ([]GeneralFixtureData, error) {
+	r.filters = append(r.filters, filter)
+	return r.MemoryFixtureRepository.FindFixtures(ctx, filter)
+}
+
+func batchRepository(t *testing.T) *recordingRepository {
+	t.Helper()
+	repo := &recordingRepository{MemoryFixtureRepository: NewMemoryFixtureRepository()}
+	day := time.Date(2024, 8, 1, 15, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		f := GeneralFixtureData{FixtureID: fmt.Sprint(i), LeagueID: "39", FixtureData: FixtureData{Date: day.AddDate(0, 0, i/2)}}
+		if err := repo.UpsertFixture(context.Background(), f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+func TestExecuteBatchFixturesPagesThroughRepository(t *testing.T) {
+	repo := batchRepository(t)
+	req := BatchFixturesRequest{Leagues: []string{"39"}, DateTo: "2024-08-03", Limit: 2}
+	var got []string
+	for page := 0; ; page++ {
+		resp, err := ExecuteBatchFixtures(context.Background(), repo, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filter := repo.filters[page]
+		if filter.Limit != 3 || (req.Cursor == "") != (filter.AfterID == "") {
+			t.Fatalf("page %d filter = %+v, want the cursor and limit+1", page, filter)
+		}
+		for _, f := range resp.Fixtures {
+			got = append(got, f.FixtureID)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		req.Cursor = resp.NextCursor
+	}
+	if want := "[0 1 2 3 4 5]"; fmt.Sprint(got) != want {
+		t.Fatalf("paged fixtures = %v, want %s", got, want)
+	}
+}
+
+func TestExecuteBatchFixturesItemErrorsOnFirstPage(t *testing.T) {
+	repo := batchRepository(t)
+	req := BatchFixturesRequest{FixtureIDs: []string{"4", "missing", "1", "2", "1"}, Limit: 2}
+	resp, err := ExecuteBatchFixtures(context.Background(), repo, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Fixtures) != 2 || resp.Fixtures[0].FixtureID != "1" || resp.Fixtures[1].FixtureID != "2" {
+		t.Fatalf("first page = %+v", resp.Fixtures)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].FixtureID != "missing" || resp.Errors[0].Error.Code != CodeNotFound {
+		t.Fatalf("first page errors = %+v", resp.Errors)
+	}
+
+	req.Cursor = resp.NextCursor
+	resp, err = ExecuteBatchFixtures(context.Background(), repo, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Fixtures) != 1 || resp.Fixtures[0].FixtureID != "4" || resp.NextCursor != "" {
+		t.Fatalf("second page = %+v", resp)
+	}
+	if len(resp.Errors) != 0 {
+		t.Fatalf("second page repeats errors %+v", resp.Errors)
+	}
+}
+
+func TestBatchFixturesRequestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  BatchFixturesRequest
+		ok   bool
+	}{
+		{"ids", BatchFixturesRequest{FixtureIDs: []string{"1"}}, true},
+		{"no selection", BatchFixturesRequest{Statuses: []string{"FT"}}, false},
+		{"bad date", BatchFixturesRequest{DateFrom: "01/08/2024"}, false},
+		{"reversed dates", BatchFixturesRequest{DateFrom: "2024-08-02", DateTo: "2024-08-01"}, false},
+		{"limit", BatchFixturesRequest{Leagues: []string{"39"}, Limit: maxBatchLimit + 1}, false},
+		{"cursor", BatchFixturesRequest{Leagues: []string{"39"}, Cursor: "!"}, false},
+	}
+	for _, tt := range tests {
+		err := tt.req.Validate()
+		if (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrInvalidRequest)) {
+			t.Errorf("%s: Validate = %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrCassetteMiss is returned by a Replayer when no recorded interaction matches a request.
+var ErrCassetteMiss = errors.New("no recorded interaction matches request")
+
+// DefaultMatchParams are the query parameters a Replayer compares besides the path.
+var DefaultMatchParams = []string{"id", "league", "season", "date", "fixture", "team", "round", "from", "to", "status", "timezone"}
+
+// DefaultScrubParams are the query parameters carrying API keys.
+var DefaultScrubParams = []string{"key", "apikey", "api_key"}
+
+// scrubbedValue replaces secrets in recorded interactions.
+const scrubbedValue = "REDACTED"
+
+// Cassette holds recorded upstream request/response pairs. ScrubbedParams
+// names the query parameters scrubbed while recording, which replay never
+// compares; nil stands for DefaultScrubParams.
+type Cassette struct {
+	ScrubbedParams []string      `json:"scrubbed_params,omitempty"`
+	Interactions   []Interaction `json:"interactions"`
+}
+
+func (c *Cassette) scrubbed(name string) bool {
+	params := c.ScrubbedParams
+	if params == nil {
+		params = DefaultScrubParams
+	}
+	return containsString(params, name)
+}
+
+// Interaction is a single recorded request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of an upstream request used to match it on replay.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  url.Values  `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// RecordedResponse is an upstream response. JSON bodies are stored inline to
+// keep testdata files readable, any other body is stored as text.
+type RecordedResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	BodyText   string          `json:"body_text,omitempty"`
+}
+
+func (r RecordedResponse) body() []byte {
+	if len(r.Body) > 0 {
+		return r.Body
+	}
+	return []byte(r.BodyText)
+}
+
+// LoadCassette reads a cassette from a JSON file.
+func LoadCassette(path string) (*Cassette, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("decode cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to a JSON file, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(raw, '\n'), 0o644)
+}
+
+// Recorder is an http.RoundTripper that forwards requests to the upstream API
+// and records every interaction with API keys scrubbed from headers and query.
+type Recorder struct {
+	// Base performs the actual requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// Path is the file Save writes the cassette to.
+	Path string
+	// ScrubHeaders and ScrubParams name the request headers and query
+	// parameters whose values are replaced before recording.
+	ScrubHeaders []string
+	ScrubParams  []string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder writing to path that scrubs the API-Football and RapidAPI keys.
+func NewRecorder(path string, base http.RoundTripper) *Recorder {
+	return &Recorder{
+		Base:         base,
+		Path:         path,
+		ScrubHeaders: []string{HeaderAPIKey, "x-rapidapi-key", "Authorization"},
+		ScrubParams:  DefaultScrubParams,
+	}
+}
+
+// RoundTrip performs the request and records the interaction.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := r.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := RecordedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone()}
+	if json.Valid(body) {
+		recorded.Body = append(json.RawMessage(nil), body...)
+	} else {
+		recorded.BodyText = string(body)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  r.scrub(req),
+		Response: recorded,
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{
+		ScrubbedParams: append([]string{}, r.ScrubParams...),
+		Interactions:   append([]Interaction(nil), r.cassette.Interactions...),
+	}
+}
+
+// Save writes the recorded interactions to the recorder's path.
+func (r *Recorder) Save() error {
+	c := r.Cassette()
+	return c.Save(r.Path)
+}
+
+func (r *Recorder) scrub(req *http.Request) RecordedRequest {
+	header := req.Header.Clone()
+	for _, name := range r.ScrubHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, scrubbedValue)
+		}
+	}
+	query := req.URL.Query()
+	for _, name := range r.ScrubParams {
+		if query.Has(name) {
+			query.Set(name, scrubbedValue)
+		}
+	}
+	if len(header) == 0 {
+		header = nil
+	}
+	if len(query) == 0 {
+		query = nil
+	}
+	return RecordedRequest{Method: req.Method, Path: req.URL.Path, Query: query, Header: header}
+}
+
+// Replayer is an http.RoundTripper that serves responses from a cassette
+// without touching the network. Requests match an interaction on method, path
+// and the MatchParams query parameters, never on scrubbed ones. When several interactions match the
+// same request they are served in recorded order, repeating the last one.
+type Replayer struct {
+	// MatchParams are the query parameters compared. Nil compares the whole query.
+	MatchParams []string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	served   map[int]bool
+}
+
+// NewReplayer returns a Replayer over the cassette stored at path, matching on DefaultMatchParams.
+func NewReplayer(path string) (*Replayer, error) {
+	c, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCassetteReplayer(c), nil
+}
+
+// NewCassetteReplayer returns a Replayer over an in-memory cassette, matching on DefaultMatchParams.
+func NewCassetteReplayer(c *Cassette) *Replayer {
+	return &Replayer{MatchParams: DefaultMatchParams, cassette: c, served: make(map[int]bool)}
+}
+
+// RoundTrip returns the recorded response for the request or an error wrapping ErrCassetteMiss.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cassette == nil {
+		r.cassette = &Cassette{}
+	}
+	if r.served == nil {
+		r.served = make(map[int]bool)
+	}
+
+	want := r.matchKey(req.Method, req.URL.Path, req.URL.Query())
+	match := -1
+	for i, in := range r.cassette.Interactions {
+		if r.matchKey(in.Request.Method, in.Request.Path, in.Request.Query) != want {
+			continue
+		}
+		match = i
+		if !r.served[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCassetteMiss, want)
+	}
+	r.served[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	body := recorded.body()
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// matchKey renders the parts of a request compared during replay.
+func (r *Replayer) matchKey(method, path string, query url.Values) string {
+	if method == "" {
+		method = http.MethodGet
+	}
+	selected := url.Values{}
+	for name, values := range query {
+		if r.cassette.scrubbed(name) || (r.MatchParams != nil && !containsString(r.MatchParams, name)) {
+			continue
+		}
+		selected[name] = values
+	}
+
+	keys := make([]string, 0, len(selected))
+	for k := range selected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+strings.Join(selected[k], ","))
+	}
+	return method + " " + path + "?" + strings.Join(parts, "&")
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func cassetteGet(t *testing.T, client *http.Client, url string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(HeaderAPIKey, "live-secret")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Saved cassettes indent JSON bodies.
+	var compact bytes.Buffer
+	if json.Compact(&compact, body) == nil {
+		body = compact.Bytes()
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+func TestCassetteRoundTrip(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if r.URL.Path == "/status" {
+			io.WriteString(w, "ok")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"league":"`+r.URL.Query().Get("league")+`","call":`+string(rune('0'+n))+`}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	path := filepath.Join(t.TempDir(), "testdata", "fixtures.json")
+	rec := NewRecorder(path, nil)
+	live := &http.Client{Transport: rec}
+	for _, url := range []string{
+		"/fixtures?league=39&season=2023&key=secret",
+		"/fixtures?league=39&season=2023&key=secret",
+		"/fixtures?league=140&season=2023&key=secret",
+		"/status",
+	} {
+		if _, _, err := cassetteGet(t, live, srv.URL+url); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "secret") {
+		t.Fatalf("cassette leaks a key:\n%s", raw)
+	}
+
+	for _, matchParams := range [][]string{DefaultMatchParams, nil} {
+		replayer, err := NewReplayer(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		replayer.MatchParams = matchParams
+		offline := &http.Client{Transport: replayer}
+
+		// The live key differs from the scrubbed one and must not matter.
+		for _, tt := range []struct{ url, want string }{
+			{"/fixtures?season=2023&league=39&key=other", `{"league":"39","call":1}`},
+			{"/fixtures?league=39&season=2023&key=other", `{"league":"39","call":2}`},
+			{"/fixtures?league=39&season=2023&key=other", `{"league":"39","call":2}`},
+			{"/fixtures?league=140&season=2023", `{"league":"140","call":3}`},
+			{"/status", "ok"},
+		} {
+			status, body, err := cassetteGet(t, offline, "http://replay.invalid"+tt.url)
+			if err != nil {
+				t.Fatalf("MatchParams %v, %s: %v", matchParams, tt.url, err)
+			}
+			if status != http.StatusOK || body != tt.want {
+				t.Fatalf("MatchParams %v, %s = %d %s, want %s", matchParams, tt.url, status, body, tt.want)
+			}
+		}
+
+		_, _, err = cassetteGet(t, offline, "http://replay.invalid/fixtures?league=39&season=2024")
+		if !errors.Is(err, ErrCassetteMiss) {
+			t.Fatalf("unrecorded request = %v, want ErrCassetteMiss", err)
+		}
+	}
+	if n := calls.Load(); n != 4 {
+		t.Fatalf("upstream saw %d requests, want 4", n)
+	}
+}
+
+func TestCassetteScrubsHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+	rec := NewRecorder("", nil)
+	if _, _, err := cassetteGet(t, &http.Client{Transport: rec}, srv.URL+"/leagues?apikey=secret"); err != nil {
+		t.Fatal(err)
+	}
+	got := rec.Cassette().Interactions[0].Request
+	if v := got.Header.Get(HeaderAPIKey); v != scrubbedValue {
+		t.Fatalf("%s = %q, want scrubbed", HeaderAPIKey, v)
+	}
+	if v := got.Query.Get("apikey"); v != scrubbedValue {
+		t.Fatalf("apikey = %q, want scrubbed", v)
+	}
+}
+
+func TestReplayerZeroValue(t *testing.T) {
+	_, _, err := cassetteGet(t, &http.Client{Transport: &Replayer{}}, "http://replay.invalid/status")
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("zero Replayer = %v, want ErrCassetteMiss", err)
+	}
+}
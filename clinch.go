@@ -0,0 +1,224 @@
+package client
+
+import (
+	"sort"
+	"strings"
+)
+
+// titleZone is the zone added for first place when no described zone covers it alone.
+const titleZone = "Title"
+
+// Zone is a range of final positions with a meaning, such as Champions
+// League qualification or relegation, as described in TeamStanding.Description.
+type Zone struct {
+	Name       string `json:"name" bson:"name"`
+	From       int    `json:"from" bson:"from"`
+	To         int    `json:"to" bson:"to"`
+	Relegation bool   `json:"relegation" bson:"relegation"`
+}
+
+// ZoneStatus is a team's mathematical standing against a zone. Clinched means
+// the team finishes within the zone whatever the remaining results, Eliminated
+// that it cannot. For relegation zones MagicNumber is the points the team
+// still needs to finish above the zone, for other zones the points it needs to
+// finish within it, whatever its rivals do; it is 0 once secured.
+type ZoneStatus struct {
+	Zone        Zone `json:"zone" bson:"zone"`
+	Clinched    bool `json:"clinched" bson:"clinched"`
+	Eliminated  bool `json:"eliminated" bson:"eliminated"`
+	MagicNumber int  `json:"magic_number" bson:"magic_number"`
+}
+
+// TeamRace summarises a team's run-in and its standing against each zone.
+type TeamRace struct {
+	TeamID    int          `json:"team_id" bson:"team_id"`
+	Team      string       `json:"team" bson:"team"`
+	Rank      int          `json:"rank" bson:"rank"`
+	Points    int          `json:"points" bson:"points"`
+	Remaining int          `json:"remaining" bson:"remaining"`
+	MaxPoints int          `json:"max_points" bson:"max_points"`
+	Zones     []ZoneStatus `json:"zones" bson:"zones"`
+}
+
+// ZonesFromStandings derives zones from runs of consecutive ranks sharing a
+// non-empty Description. A title zone for first place is added unless a
+// described zone already covers first place alone.
+func ZonesFromStandings(standings []TeamStanding) []Zone {
+	sorted := append([]TeamStanding(nil), standings...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Rank < sorted[j].Rank })
+
+	var zones []Zone
+	for _, s := range sorted {
+		if s.Description == "" {
+			continue
+		}
+		if n := len(zones); n > 0 && zones[n-1].Name == s.Description && zones[n-1].To == s.Rank-1 {
+			zones[n-1].To = s.Rank
+			continue
+		}
+		zones = append(zones, Zone{
+			Name:       s.Description,
+			From:       s.Rank,
+			To:         s.Rank,
+			Relegation: strings.Contains(strings.ToLower(s.Description), "relegation"),
+		})
+	}
+	if len(zones) == 0 || zones[0].From != 1 || zones[0].To != 1 {
+		zones = append([]Zone{{Name: titleZone, From: 1, To: 1}}, zones...)
+	}
+	return zones
+}
+
+// ComputeRace works out, for every team in standings, whether it has clinched
+// or been eliminated from each zone given the fixtures still to be played in
+// fixtures, and its magic numbers. Bounds treat every rival's run-in
+// independently, so a clinch or elimination is only reported when it is
+// certain, though it may be reported later than the earliest possible point.
+//
+// Ties on points are taken to go either way unless headToHead is set, in which
+// case a tie between exactly two teams whose mutual fixtures are all played
+// goes to the team ahead in their head-to-head record.
+func ComputeRace(standings []Standings, fixtures []GeneralFixtureData, zones []Zone, headToHead bool) []TeamRace {
+	latest := LatestFixtures(fixtures)
+	remaining := make(map[int]int)
+	pending := make(map[[2]int]bool)
+	var played []GeneralFixtureData
+	for _, g := range latest {
+		f := g.FixtureData
+		switch {
+		case f.Outcome().InSchedule():
+			remaining[f.HomeTeamID]++
+			remaining[f.AwayTeamID]++
+			pending[teamPair(f.HomeTeamID, f.AwayTeamID)] = true
+		case f.Outcome().CountsInStandings():
+			played = append(played, g)
+		}
+	}
+
+	r := race{teams: make([]TeamRace, len(standings))}
+	for i, s := range standings {
+		r.teams[i] = TeamRace{
+			TeamID:    s.TeamID,
+			Team:      s.Team,
+			Rank:      s.Rank,
+			Points:    s.Points,
+			Remaining: remaining[s.TeamID],
+			MaxPoints: s.Points + pointsWin*remaining[s.TeamID],
+		}
+	}
+	if headToHead {
+		r.winsTie = make(map[[2]int]bool)
+		for i, a := range standings {
+			for _, b := range standings[i+1:] {
+				if pending[teamPair(a.TeamID, b.TeamID)] {
+					continue
+				}
+				mini := HeadToHeadTable([]Standings{a, b}, played)
+				if compareRecord(mini[0], mini[1]) != 0 {
+					r.winsTie[[2]int{mini[0].TeamID, mini[1].TeamID}] = true
+				}
+			}
+		}
+	}
+
+	for i := range r.teams {
+		for _, z := range zones {
+			r.teams[i].Zones = append(r.teams[i].Zones, r.zoneStatus(i, z))
+		}
+	}
+	return r.teams
+}
+
+type race struct {
+	teams   []TeamRace
+	winsTie map[[2]int]bool
+}
+
+func (r race) zoneStatus(i int, z Zone) ZoneStatus {
+	t := r.teams[i]
+	worst := 1 + r.canFinishAbove(i, t.Points)
+	best := 1 + r.alwaysAbove(i)
+
+	status := ZoneStatus{
+		Zone:       z,
+		Clinched:   worst <= z.To && best >= z.From,
+		Eliminated: best > z.To || worst < z.From,
+	}
+	if z.Relegation {
+		status.MagicNumber = r.pointsToFinishBy(i, z.From-1)
+	} else {
+		status.MagicNumber = r.pointsToFinishBy(i, z.To)
+	}
+	return status
+}
+
+// canFinishAbove counts the teams that could finish above team i when it ends on points.
+func (r race) canFinishAbove(i, points int) int {
+	n := 0
+	for j, o := range r.teams {
+		if j == i {
+			continue
+		}
+		if o.MaxPoints > points || (o.MaxPoints == points && !r.tieGoesTo(i, j, points)) {
+			n++
+		}
+	}
+	return n
+}
+
+// alwaysAbove counts the teams that finish above team i whatever the results.
+func (r race) alwaysAbove(i int) int {
+	t := r.teams[i]
+	n := 0
+	for j, o := range r.teams {
+		if j == i {
+			continue
+		}
+		if o.Points > t.MaxPoints || (o.Points == t.MaxPoints && r.tieGoesTo(j, i, t.MaxPoints)) {
+			n++
+		}
+	}
+	return n
+}
+
+// tieGoesTo reports whether a tie on points between teams a and b is certain
+// to go to a: their head-to-head decides it and no third team can end on points.
+func (r race) tieGoesTo(a, b, points int) bool {
+	if !r.winsTie[[2]int{r.teams[a].TeamID, r.teams[b].TeamID}] {
+		return false
+	}
+	for k, o := range r.teams {
+		if k != a && k != b && o.Points <= points && points <= o.MaxPoints {
+			return false
+		}
+	}
+	return true
+}
+
+// pointsToFinishBy returns the points team i needs to be certain of finishing
+// at rank or better. It is 0 once certain and may exceed what the team can
+// still win. A rank below 1 needs nothing.
+func (r race) pointsToFinishBy(i, rank int) int {
+	if rank < 1 {
+		return 0
+	}
+	t := r.teams[i]
+	top := t.Points
+	for _, o := range r.teams {
+		top = max(top, o.MaxPoints)
+	}
+	for m := 0; t.Points+m <= top; m++ {
+		if r.canFinishAbove(i, t.Points+m) < rank {
+			return m
+		}
+	}
+	return top + 1 - t.Points
+}
+
+// teamPair returns a key for two teams independent of their order.
+func teamPair(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestZonesFromStandings(t *testing.T) {
+	tests := []struct {
+		name        string
+		description []string
+		want        string
+	}{
+		{"title added", []string{"UCL", "UCL", "", "Relegation"},
+			"[{Title 1 1 false} {UCL 1 2 false} {Relegation 4 4 true}]"},
+		{"first place described alone", []string{"Champion", "UCL", "UCL", ""},
+			"[{Champion 1 1 false} {UCL 2 3 false}]"},
+		{"split by a gap", []string{"UEL", "", "UEL", "Relegation Round"},
+			"[{UEL 1 1 false} {UEL 3 3 false} {Relegation Round 4 4 true}]"},
+	}
+	for _, tt := range tests {
+		var standings []TeamStanding
+		// Given out of rank order.
+		for i := len(tt.description) - 1; i >= 0; i-- {
+			standings = append(standings, TeamStanding{Rank: i + 1, Description: tt.description[i]})
+		}
+		if got := fmt.Sprint(ZonesFromStandings(standings)); got != tt.want {
+			t.Errorf("%s: zones = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+var raceZones = []Zone{
+	{Name: titleZone, From: 1, To: 1},
+	{Name: "UCL", From: 1, To: 2},
+	{Name: "Relegation", From: 4, To: 4, Relegation: true},
+}
+
+// raceSeason has T1 level with T2's maximum, T1 having won their meetings.
+// Still to play: T2-T3 and T3-T4, so the maxima are 30, 30, 20+6 and 13.
+func raceSeason(pointsT3 int) ([]Standings, []GeneralFixtureData) {
+	standings := []Standings{
+		{TeamID: 1, Team: "T1", Rank: 1, Points: 30},
+		{TeamID: 2, Team: "T2", Rank: 2, Points: 27},
+		{TeamID: 3, Team: "T3", Rank: 3, Points: pointsT3},
+		{TeamID: 4, Team: "T4", Rank: 4, Points: 10},
+	}
+	fixtures := []GeneralFixtureData{meeting(1, 2, 2, 0), meeting(2, 1, 1, 1)}
+	for _, g := range []GeneralFixtureData{meeting(2, 3, 0, 0), meeting(3, 4, 0, 0)} {
+		g.FixtureData.GameStatus = StatusNotStarted
+		fixtures = append(fixtures, g)
+	}
+	return standings, fixtures
+}
+
+func TestComputeRace(t *testing.T) {
+	type want struct {
+		team, zone           int
+		clinched, eliminated bool
+		magic                int
+	}
+	tests := []struct {
+		name       string
+		pointsT3   int
+		headToHead bool
+		want       []want
+	}{
+		{"ties either way", 20, false, []want{
+			// T2 can still draw level on 30, so T1 needs one more point.
+			{1, 0, false, false, 1},
+			{2, 0, false, false, 4},
+			{1, 1, true, false, 0},
+			// T3 cannot end below T4; T4 cannot pass T3's 26 below 27 points.
+			{3, 2, false, true, 0},
+			{4, 2, true, false, 17},
+		}},
+		{"head-to-head decides a tie at the top", 20, true, []want{
+			{1, 0, true, false, 0},
+			{2, 0, false, true, 4},
+		}},
+		{"a third team can reach the tie", 24, true, []want{
+			{1, 0, false, false, 1},
+			{2, 0, false, false, 4},
+		}},
+	}
+	for _, tt := range tests {
+		standings, fixtures := raceSeason(tt.pointsT3)
+		race := ComputeRace(standings, fixtures, raceZones, tt.headToHead)
+		if race[1].Remaining != 1 || race[2].MaxPoints != tt.pointsT3+6 {
+			t.Fatalf("%s: run-ins = %+v", tt.name, race)
+		}
+		for _, w := range tt.want {
+			got := race[w.team-1].Zones[w.zone]
+			if got.Clinched != w.clinched || got.Eliminated != w.eliminated || got.MagicNumber != w.magic {
+				t.Errorf("%s: T%d %s = %+v, want clinched %v, eliminated %v, magic %d",
+					tt.name, w.team, got.Zone.Name, got, w.clinched, w.eliminated, w.magic)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so that schedulers and rate limiters can be driven by a fake clock in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by the time package.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+func (SystemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// ManualClock is a Clock that only moves when Advance or Set is called.
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []manualWaiter
+}
+
+type manualWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewManualClock returns a ManualClock set to now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the clock's current time.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the clock's time once it has been advanced by at least d.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, manualWaiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, firing any After channels that become due.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(c.now.Add(d))
+}
+
+// Set moves the clock to t, firing any After channels that become due.
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLocked(t)
+}
+
+// Waiters returns the number of pending After channels, which lets tests wait
+// until a goroutine is blocked on the clock before advancing it.
+func (c *ManualClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+func (c *ManualClock) setLocked(t time.Time) {
+	c.now = t
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if !w.deadline.After(t) {
+			w.ch <- t
+			continue
+		}
+		pending = append(pending, w)
+	}
+	c.waiters = pending
+}
@@ -0,0 +1,380 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultDecayPerDay halves a match's weight in about a year.
+	defaultDecayPerDay   = 0.0019
+	defaultFitIterations = 500
+	defaultFitTolerance  = 1e-7
+	// fitDamping scales every Newton step to keep the fit stable.
+	fitDamping = 0.8
+	// maxRho bounds the low-score correction so that it stays a valid adjustment.
+	maxRho = 0.3
+)
+
+// DixonColesFitter fits a DixonColesModel to finished fixtures, weighting each
+// by exp(-DecayPerDay * days before At).
+type DixonColesFitter struct {
+	// DecayPerDay is the time-decay rate; zero weighs every match equally.
+	DecayPerDay float64
+	// At is the date the model is fitted for. Only fixtures that kicked off
+	// before it are used. Zero means just after the last fixture.
+	At time.Time
+	// MaxIterations bounds the number of optimisation steps.
+	MaxIterations int
+	// Tolerance stops the fit once no parameter moves by more than it.
+	Tolerance float64
+}
+
+// NewDixonColesFitter returns a DixonColesFitter with default decay and convergence settings.
+func NewDixonColesFitter() *DixonColesFitter {
+	return &DixonColesFitter{
+		DecayPerDay:   defaultDecayPerDay,
+		MaxIterations: defaultFitIterations,
+		Tolerance:     defaultFitTolerance,
+	}
+}
+
+// DixonColesModel is a fitted Dixon-Coles model. The home side's mean goals
+// are exp(home attack + away defence + HomeAdvantage), the away side's
+// exp(away attack + home defence), and Rho corrects the probabilities of the
+// 0-0, 1-0, 0-1 and 1-1 scores. Attacks average zero.
+type DixonColesModel struct {
+	Teams         []DixonColesTeam `json:"teams" bson:"teams"`
+	HomeAdvantage float64          `json:"home_advantage" bson:"home_advantage"`
+	Rho           float64          `json:"rho" bson:"rho"`
+	FittedAt      time.Time        `json:"fitted_at" bson:"fitted_at"`
+	Matches       int              `json:"matches" bson:"matches"`
+	Iterations    int              `json:"iterations" bson:"iterations"`
+	Converged     bool             `json:"converged" bson:"converged"`
+	LogLikelihood float64          `json:"log_likelihood" bson:"log_likelihood"`
+
+	index map[int]int
+}
+
+// DixonColesTeam holds a team's fitted parameters. A higher Defence concedes more.
+type DixonColesTeam struct {
+	TeamID  int     `json:"team_id" bson:"team_id"`
+	Team    string  `json:"team" bson:"team"`
+	Attack  float64 `json:"attack" bson:"attack"`
+	Defence float64 `json:"defence" bson:"defence"`
+	Matches int     `json:"matches" bson:"matches"`
+}
+
+// dcMatch is a fixture prepared for fitting.
+type dcMatch struct {
+	home, away int
+	goalsHome  int
+	goalsAway  int
+	weight     float64
+}
+
+// Fit fits the model to the played fixtures. It fails when there is none.
+func (f *DixonColesFitter) Fit(fixtures []GeneralFixtureData) (*DixonColesModel, error) {
+	at := f.At
+	if at.IsZero() {
+		for _, g := range fixtures {
+			if d := g.FixtureData.Date; d.After(at) {
+				at = d
+			}
+		}
+		at = at.Add(time.Nanosecond)
+	}
+
+	model := &DixonColesModel{FittedAt: at, index: make(map[int]int)}
+	team := func(id int, name string) int {
+		i, ok := model.index[id]
+		if !ok {
+			i = len(model.Teams)
+			model.index[id] = i
+			model.Teams = append(model.Teams, DixonColesTeam{TeamID: id, Team: name})
+		}
+		return i
+	}
+
+	var matches []dcMatch
+	var homeGoals, awayGoals, weights float64
+	for _, g := range LatestFixtures(fixtures) {
+		fd := g.FixtureData
+		if !fd.Outcome().CountsInStatistics() || !fd.Date.Before(at) {
+			continue
+		}
+		days := at.Sub(fd.Date).Hours() / 24
+		m := dcMatch{
+			home:      team(fd.HomeTeamID, fd.HomeTeam),
+			away:      team(fd.AwayTeamID, fd.AwayTeam),
+			goalsHome: fd.GoalsHome,
+			goalsAway: fd.GoalsAway,
+			weight:    math.Exp(-f.DecayPerDay * days),
+		}
+		model.Teams[m.home].Matches++
+		model.Teams[m.away].Matches++
+		matches = append(matches, m)
+		homeGoals += m.weight * float64(m.goalsHome)
+		awayGoals += m.weight * float64(m.goalsAway)
+		weights += m.weight
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no finished fixtures before %s to fit", at.Format(time.RFC3339))
+	}
+	model.Matches = len(matches)
+
+	// Start from a league where every team is average.
+	homeMean := math.Max(homeGoals/weights, 0.1)
+	awayMean := math.Max(awayGoals/weights, 0.1)
+	model.HomeAdvantage = math.Log(homeMean / awayMean)
+	for i := range model.Teams {
+		model.Teams[i].Defence = math.Log(awayMean)
+	}
+
+	maxIter := f.MaxIterations
+	if maxIter <= 0 {
+		maxIter = defaultFitIterations
+	}
+	tol := f.Tolerance
+	if tol <= 0 {
+		tol = defaultFitTolerance
+	}
+	for model.Iterations < maxIter && !model.Converged {
+		model.Iterations++
+		model.Converged = model.step(matches) < tol
+	}
+	model.LogLikelihood = model.logLikelihood(matches)
+	return model, nil
+}
+
+// Parameter blocks updated in turn. Within a block no two parameters share a
+// goal mean, so a diagonal Newton step is exact for the Poisson terms.
+const (
+	blockAttack = iota
+	blockDefence
+	blockHome
+	blockRho
+)
+
+// step performs one damped Newton pass over each parameter block in turn and
+// returns the largest change.
+func (m *DixonColesModel) step(matches []dcMatch) float64 {
+	var change float64
+	for block := blockAttack; block <= blockRho; block++ {
+		change = math.Max(change, m.stepBlock(matches, block))
+	}
+
+	// Attacks and defences trade off freely: centre attacks on zero.
+	var mean float64
+	for _, t := range m.Teams {
+		mean += t.Attack
+	}
+	mean /= float64(len(m.Teams))
+	for i := range m.Teams {
+		m.Teams[i].Attack -= mean
+		m.Teams[i].Defence += mean
+	}
+	return change
+}
+
+func (m *DixonColesModel) stepBlock(matches []dcMatch, block int) float64 {
+	n := len(m.Teams)
+	grad, hess := make([]float64, n), make([]float64, n)
+	var gradOne, hessOne float64
+
+	for _, x := range matches {
+		lambda, mu := m.means(x.home, x.away)
+		dLambda, dMu, dRho := tauGradient(x.goalsHome, x.goalsAway, lambda, mu, m.Rho)
+		gLambda := x.weight * (float64(x.goalsHome) - lambda + dLambda)
+		gMu := x.weight * (float64(x.goalsAway) - mu + dMu)
+		switch block {
+		case blockAttack:
+			grad[x.home] += gLambda
+			hess[x.home] += x.weight * lambda
+			grad[x.away] += gMu
+			hess[x.away] += x.weight * mu
+		case blockDefence:
+			grad[x.away] += gLambda
+			hess[x.away] += x.weight * lambda
+			grad[x.home] += gMu
+			hess[x.home] += x.weight * mu
+		case blockHome:
+			gradOne += gLambda
+			hessOne += x.weight * lambda
+		case blockRho:
+			gradOne += x.weight * dRho
+			hessOne += x.weight * dRho * dRho
+		}
+	}
+
+	var change float64
+	move := func(p *float64, grad, hess float64) {
+		if hess <= 0 {
+			return
+		}
+		d := fitDamping * grad / hess
+		*p += d
+		change = math.Max(change, math.Abs(d))
+	}
+	switch block {
+	case blockAttack:
+		for i := range m.Teams {
+			move(&m.Teams[i].Attack, grad[i], hess[i])
+		}
+	case blockDefence:
+		for i := range m.Teams {
+			move(&m.Teams[i].Defence, grad[i], hess[i])
+		}
+	case blockHome:
+		move(&m.HomeAdvantage, gradOne, hessOne)
+	case blockRho:
+		move(&m.Rho, gradOne, hessOne)
+		m.Rho = math.Max(-maxRho, math.Min(maxRho, m.Rho))
+	}
+	return change
+}
+
+func (m *DixonColesModel) logLikelihood(matches []dcMatch) float64 {
+	var ll float64
+	for _, x := range matches {
+		lambda, mu := m.means(x.home, x.away)
+		tau := dixonColesTau(x.goalsHome, x.goalsAway, lambda, mu, m.Rho)
+		ll += x.weight * (math.Log(math.Max(tau, 1e-12)) +
+			math.Log(poissonPMF(x.goalsHome, lambda)) + math.Log(poissonPMF(x.goalsAway, mu)))
+	}
+	return ll
+}
+
+func (m *DixonColesModel) means(home, away int) (float64, float64) {
+	return m.teamMeans(m.Teams[home], m.Teams[away])
+}
+
+// teamMeans returns the mean goals of the home and away teams.
+func (m *DixonColesModel) teamMeans(h, a DixonColesTeam) (float64, float64) {
+	return math.Exp(h.Attack + a.Defence + m.HomeAdvantage), math.Exp(a.Attack + h.Defence)
+}
+
+// averageTeam returns a team with the mean attack and defence of the fitted
+// teams, which scores and concedes at the league's average rates.
+func (m *DixonColesModel) averageTeam() DixonColesTeam {
+	var avg DixonColesTeam
+	if len(m.Teams) == 0 {
+		return avg
+	}
+	for _, t := range m.Teams {
+		avg.Attack += t.Attack
+		avg.Defence += t.Defence
+	}
+	avg.Attack /= float64(len(m.Teams))
+	avg.Defence /= float64(len(m.Teams))
+	return avg
+}
+
+// Team returns the fitted parameters of a team.
+func (m *DixonColesModel) Team(teamID int) (DixonColesTeam, bool) {
+	if m.index == nil {
+		// Decoded models have no index.
+		for _, t := range m.Teams {
+			if t.TeamID == teamID {
+				return t, true
+			}
+		}
+		return DixonColesTeam{}, false
+	}
+	i, ok := m.index[teamID]
+	if !ok {
+		return DixonColesTeam{}, false
+	}
+	return m.Teams[i], true
+}
+
+// ExpectedGoals returns the mean goals of each side in the fixture. It fails
+// with a NotFound error for a team the model was not fitted on.
+func (m *DixonColesModel) ExpectedGoals(f FixtureData) (float64, float64, error) {
+	h, ok := m.Team(f.HomeTeamID)
+	if !ok {
+		return 0, 0, NotFound("team", strconv.Itoa(f.HomeTeamID))
+	}
+	a, ok := m.Team(f.AwayTeamID)
+	if !ok {
+		return 0, 0, NotFound("team", strconv.Itoa(f.AwayTeamID))
+	}
+	home, away := m.teamMeans(h, a)
+	return home, away, nil
+}
+
+// Predict returns the outcome probabilities of an upcoming fixture.
+func (m *DixonColesModel) Predict(f FixtureData) (MatchProbabilities, error) {
+	home, away, err := m.ExpectedGoals(f)
+	if err != nil {
+		return MatchProbabilities{}, err
+	}
+	return m.probabilities(home, away), nil
+}
+
+// SimulateMatch draws a score from the model's prediction, so that the model
+// can drive a Simulator. Teams the model was not fitted on, such as promoted
+// sides, play as an average team.
+func (m *DixonColesModel) SimulateMatch(f FixtureData, rng *rand.Rand) (home, away int) {
+	h, ok := m.Team(f.HomeTeamID)
+	if !ok {
+		h = m.averageTeam()
+	}
+	a, ok := m.Team(f.AwayTeamID)
+	if !ok {
+		a = m.averageTeam()
+	}
+	return m.probabilities(m.teamMeans(h, a)).Sample(rng)
+}
+
+// probabilities builds the Dixon-Coles score grid for the given means.
+func (m *DixonColesModel) probabilities(home, away float64) MatchProbabilities {
+	return scoreProbabilities(home, away, defaultMaxGoals, nil, func(h, a int) float64 {
+		return math.Max(dixonColesTau(h, a, home, away, m.Rho), 0)
+	})
+}
+
+// Ranking returns the teams ordered by attack minus defence, strongest first.
+func (m *DixonColesModel) Ranking() []DixonColesTeam {
+	teams := append([]DixonColesTeam(nil), m.Teams...)
+	sort.SliceStable(teams, func(i, j int) bool {
+		return teams[i].Attack-teams[i].Defence > teams[j].Attack-teams[j].Defence
+	})
+	return teams
+}
+
+// dixonColesTau is the Dixon-Coles correction to the probability of a low score.
+func dixonColesTau(h, a int, lambda, mu, rho float64) float64 {
+	switch {
+	case h == 0 && a == 0:
+		return 1 - lambda*mu*rho
+	case h == 0 && a == 1:
+		return 1 + lambda*rho
+	case h == 1 && a == 0:
+		return 1 + mu*rho
+	case h == 1 && a == 1:
+		return 1 - rho
+	}
+	return 1
+}
+
+// tauGradient returns the derivatives of log tau with respect to log lambda,
+// log mu and rho.
+func tauGradient(h, a int, lambda, mu, rho float64) (dLambda, dMu, dRho float64) {
+	tau := math.Max(dixonColesTau(h, a, lambda, mu, rho), 1e-12)
+	switch {
+	case h == 0 && a == 0:
+		return -lambda * mu * rho / tau, -lambda * mu * rho / tau, -lambda * mu / tau
+	case h == 0 && a == 1:
+		return lambda * rho / tau, 0, lambda / tau
+	case h == 1 && a == 0:
+		return 0, mu * rho / tau, mu / tau
+	case h == 1 && a == 1:
+		return 0, 0, -1 / tau
+	}
+	return 0, 0, 0
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func dixonColesModel() *DixonColesModel {
+	return &DixonColesModel{
+		Teams: []DixonColesTeam{
+			{TeamID: 1, Attack: 0.2, Defence: -0.1},
+			{TeamID: 2, Attack: -0.2, Defence: 0.3},
+		},
+		HomeAdvantage: 0.3,
+		Rho:           -0.1,
+	}
+}
+
+func TestDixonColesTau(t *testing.T) {
+	tests := []struct {
+		h, a int
+		want float64
+	}{
+		{0, 0, 1 - 1.5*0.8*-0.1},
+		{0, 1, 1 + 1.5*-0.1},
+		{1, 0, 1 + 0.8*-0.1},
+		{1, 1, 1.1},
+		{2, 1, 1},
+	}
+	for _, tt := range tests {
+		if got := dixonColesTau(tt.h, tt.a, 1.5, 0.8, -0.1); !approx(got, tt.want) {
+			t.Errorf("tau(%d, %d) = %v, want %v", tt.h, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestDixonColesPredict(t *testing.T) {
+	m := dixonColesModel()
+	home, away, err := m.ExpectedGoals(FixtureData{HomeTeamID: 1, AwayTeamID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !approx(home, math.Exp(0.8)) || !approx(away, math.Exp(-0.3)) {
+		t.Fatalf("expected goals = %v, %v, want e^0.8, e^-0.3", home, away)
+	}
+
+	p, err := m.Predict(FixtureData{HomeTeamID: 1, AwayTeamID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var grid float64
+	for _, row := range p.Scores {
+		for _, prob := range row {
+			grid += prob
+		}
+	}
+	if !approx(grid, 1) || !approx(p.HomeWin+p.Draw+p.AwayWin, 1) {
+		t.Fatalf("grid sums to %v, outcomes to %v", grid, p.HomeWin+p.Draw+p.AwayWin)
+	}
+	// Rho only reweighs the low scores: 2-2 keeps its Poisson ratio to 2-1.
+	if got, want := p.Score(2, 2)/p.Score(2, 1), away/2; !approx(got, want) {
+		t.Errorf("P(2-2)/P(2-1) = %v, want %v", got, want)
+	}
+	if got, want := p.Score(1, 1)/p.Score(2, 1), 2/home*1.1; !approx(got, want) {
+		t.Errorf("P(1-1)/P(2-1) = %v, want %v", got, want)
+	}
+
+	if _, err := m.Predict(FixtureData{HomeTeamID: 1, AwayTeamID: 99}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown team = %v, want not found", err)
+	}
+}
+
+func TestDixonColesSimulateUnknownTeams(t *testing.T) {
+	m := dixonColesModel()
+	rng := rand.New(rand.NewSource(1))
+	const n = 20000
+	var home, away int
+	for i := 0; i < n; i++ {
+		h, a := m.SimulateMatch(FixtureData{HomeTeamID: 98, AwayTeamID: 99}, rng)
+		home += h
+		away += a
+	}
+	// The average team has attack 0 and defence 0.1.
+	wantHome, wantAway := math.Exp(0.1+0.3), math.Exp(0.1)
+	if got := float64(home) / n; math.Abs(got-wantHome) > 0.05 {
+		t.Errorf("unknown home side scores %v per match, want about %v", got, wantHome)
+	}
+	if got := float64(away) / n; math.Abs(got-wantAway) > 0.05 {
+		t.Errorf("unknown away side scores %v per match, want about %v", got, wantAway)
+	}
+}
+
+func TestDixonColesFitRecoversParameters(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	attack := []float64{0.4, 0.1, 0, -0.2, -0.3}
+	defence := []float64{-0.3, -0.1, 0, 0.1, 0.3}
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var fixtures []GeneralFixtureData
+	for season := 0; season < 20; season++ {
+		for i := range attack {
+			for j := range attack {
+				if i == j {
+					continue
+				}
+				n := len(fixtures)
+				fixtures = append(fixtures, GeneralFixtureData{FixtureID: fmt.Sprint(n), FixtureData: FixtureData{
+					HomeTeamID: i,
+					AwayTeamID: j,
+					GoalsHome:  poissonSample(rng, math.Exp(attack[i]+defence[j]+0.25)),
+					GoalsAway:  poissonSample(rng, math.Exp(attack[j]+defence[i])),
+					GameStatus: StatusFinished,
+					Date:       start.Add(time.Duration(n) * time.Hour),
+				}})
+			}
+		}
+	}
+	fitter := NewDixonColesFitter()
+	fitter.DecayPerDay = 0
+	m, err := fitter.Fit(fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Converged || math.Abs(m.HomeAdvantage-0.25) > 0.15 {
+		t.Fatalf("fit converged %v with home advantage %v", m.Converged, m.HomeAdvantage)
+	}
+	for i, want := range attack {
+		if team, ok := m.Team(i); !ok || math.Abs(team.Attack-want) > 0.2 {
+			t.Errorf("team %d attack = %v, want about %v", i, team.Attack, want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package client
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// EloConfig configures an Elo rating run.
+type EloConfig struct {
+	// InitialRating is the rating of a team's first fixture.
+	InitialRating float64
+	// KFactor scales every rating change.
+	KFactor float64
+	// HomeAdvantage is the rating points added to the home side when
+	// computing expectations. Neutral venues are not distinguished.
+	HomeAdvantage float64
+	// GoalDifference scales changes by the winning margin: by 1.5 for two
+	// goals and by (11+margin)/8 for three or more.
+	GoalDifference bool
+}
+
+// DefaultEloConfig returns the configuration used for club football ratings.
+func DefaultEloConfig() EloConfig {
+	return EloConfig{
+		InitialRating:  1500,
+		KFactor:        20,
+		HomeAdvantage:  65,
+		GoalDifference: true,
+	}
+}
+
+// EloPoint is a team's rating after a fixture.
+type EloPoint struct {
+	FixtureID string    `json:"fixture_id" bson:"fixture_id"`
+	Date      time.Time `json:"date" bson:"date"`
+	Rating    float64   `json:"rating" bson:"rating"`
+	Change    float64   `json:"change" bson:"change"`
+}
+
+// EloMatch holds the ratings of both sides going into a fixture and the
+// home side's expected score, 1 being a certain win.
+type EloMatch struct {
+	FixtureID    string    `json:"fixture_id" bson:"fixture_id"`
+	Date         time.Time `json:"date" bson:"date"`
+	HomeTeamID   int       `json:"home_team_id" bson:"home_team_id"`
+	AwayTeamID   int       `json:"away_team_id" bson:"away_team_id"`
+	HomeRating   float64   `json:"home_rating" bson:"home_rating"`
+	AwayRating   float64   `json:"away_rating" bson:"away_rating"`
+	HomeExpected float64   `json:"home_expected" bson:"home_expected"`
+}
+
+// TeamRating is a team's current rating.
+type TeamRating struct {
+	TeamID  int     `json:"team_id" bson:"team_id"`
+	Team    string  `json:"team" bson:"team"`
+	Rating  float64 `json:"rating" bson:"rating"`
+	Matches int     `json:"matches" bson:"matches"`
+}
+
+// EloRatings holds running Elo ratings over a fixture history. Ratings carry
+// across seasons and competitions, teams being identified by id. The zero
+// value holds no ratings and a zero Config.
+type EloRatings struct {
+	Config EloConfig
+
+	teams    map[int]*TeamRating
+	history  map[int][]EloPoint
+	prematch map[string]EloMatch
+}
+
+// NewEloRatings returns empty ratings using cfg.
+func NewEloRatings(cfg EloConfig) *EloRatings {
+	return &EloRatings{
+		Config:   cfg,
+		teams:    make(map[int]*TeamRating),
+		history:  make(map[int][]EloPoint),
+		prematch: make(map[string]EloMatch),
+	}
+}
+
+// ComputeElo rates teams over the played fixtures in kickoff order.
+func ComputeElo(fixtures []GeneralFixtureData, cfg EloConfig) *EloRatings {
+	r := NewEloRatings(cfg)
+	sorted := LatestFixtures(fixtures)
+	sortFixtures(sorted)
+	for _, g := range sorted {
+		r.Update(g)
+	}
+	return r
+}
+
+// Update applies a played fixture, which must kick off after every fixture
+// applied before it, and returns the ratings going into it. Fixtures that were
+// not played leave the ratings unchanged and report false.
+func (r *EloRatings) Update(g GeneralFixtureData) (EloMatch, bool) {
+	f := g.FixtureData
+	if !f.Outcome().CountsInStatistics() {
+		return EloMatch{}, false
+	}
+	if r.teams == nil {
+		r.teams = make(map[int]*TeamRating)
+	}
+	if r.history == nil {
+		r.history = make(map[int][]EloPoint)
+	}
+	if r.prematch == nil {
+		r.prematch = make(map[string]EloMatch)
+	}
+	home := r.team(f.HomeTeamID, f.HomeTeam)
+	away := r.team(f.AwayTeamID, f.AwayTeam)
+	match := r.match(g.FixtureID, f, home.Rating, away.Rating)
+
+	actual := 0.5
+	switch {
+	case f.GoalsHome > f.GoalsAway:
+		actual = 1
+	case f.GoalsHome < f.GoalsAway:
+		actual = 0
+	}
+	change := r.Config.KFactor * (actual - match.HomeExpected)
+	if r.Config.GoalDifference {
+		change *= goalDifferenceMultiplier(f.GoalsHome - f.GoalsAway)
+	}
+
+	home.Rating += change
+	away.Rating -= change
+	home.Matches++
+	away.Matches++
+	r.history[home.TeamID] = append(r.history[home.TeamID], EloPoint{FixtureID: g.FixtureID, Date: f.Date, Rating: home.Rating, Change: change})
+	r.history[away.TeamID] = append(r.history[away.TeamID], EloPoint{FixtureID: g.FixtureID, Date: f.Date, Rating: away.Rating, Change: -change})
+	r.prematch[g.FixtureID] = match
+	return match, true
+}
+
+// Rating returns a team's current rating, the initial rating for unknown teams.
+func (r *EloRatings) Rating(teamID int) float64 {
+	if t, ok := r.teams[teamID]; ok {
+		return t.Rating
+	}
+	return r.Config.InitialRating
+}
+
+// RatingAt returns a team's rating going into a fixture kicking off at t.
+func (r *EloRatings) RatingAt(teamID int, t time.Time) float64 {
+	history := r.history[teamID]
+	i := sort.Search(len(history), func(i int) bool { return !history[i].Date.Before(t) })
+	if i == 0 {
+		return r.Config.InitialRating
+	}
+	return history[i-1].Rating
+}
+
+// History returns a team's rating after each of its fixtures, oldest first.
+func (r *EloRatings) History(teamID int) []EloPoint {
+	return append([]EloPoint(nil), r.history[teamID]...)
+}
+
+// PreMatch returns the ratings going into a fixture: as recorded for a
+// fixture already applied, and as of its kickoff for any other.
+func (r *EloRatings) PreMatch(g GeneralFixtureData) EloMatch {
+	if m, ok := r.prematch[g.FixtureID]; ok {
+		return m
+	}
+	f := g.FixtureData
+	return r.match(g.FixtureID, f, r.RatingAt(f.HomeTeamID, f.Date), r.RatingAt(f.AwayTeamID, f.Date))
+}
+
+// Table returns every rated team, highest rating first.
+func (r *EloRatings) Table() []TeamRating {
+	table := make([]TeamRating, 0, len(r.teams))
+	for _, t := range r.teams {
+		table = append(table, *t)
+	}
+	sort.Slice(table, func(i, j int) bool {
+		if table[i].Rating != table[j].Rating {
+			return table[i].Rating > table[j].Rating
+		}
+		return table[i].TeamID < table[j].TeamID
+	})
+	return table
+}
+
+// EloExpected returns the expected score of a side rated rating against
+// opponent, 1 being a certain win.
+func EloExpected(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/400))
+}
+
+func (r *EloRatings) match(fixtureID string, f FixtureData, home, away float64) EloMatch {
+	return EloMatch{
+		FixtureID:    fixtureID,
+		Date:         f.Date,
+		HomeTeamID:   f.HomeTeamID,
+		AwayTeamID:   f.AwayTeamID,
+		HomeRating:   home,
+		AwayRating:   away,
+		HomeExpected: EloExpected(home+r.Config.HomeAdvantage, away),
+	}
+}
+
+func (r *EloRatings) team(id int, name string) *TeamRating {
+	t, ok := r.teams[id]
+	if !ok {
+		t = &TeamRating{TeamID: id, Team: name, Rating: r.Config.InitialRating}
+		r.teams[id] = t
+	}
+	if name != "" {
+		t.Team = name
+	}
+	return t
+}
+
+// goalDifferenceMultiplier scales a rating change by the winning margin.
+func goalDifferenceMultiplier(diff int) float64 {
+	if diff < 0 {
+		diff = -diff
+	}
+	switch {
+	case diff <= 1:
+		return 1
+	case diff == 2:
+		return 1.5
+	}
+	return (11 + float64(diff)) / 8
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEloExpected(t *testing.T) {
+	tests := []struct {
+		rating, opponent, want float64
+	}{
+		{1500, 1500, 0.5},
+		{1600, 1500, 0.6400649998},
+		{1500, 1600, 0.3599350002},
+		{1900, 1500, 10.0 / 11},
+	}
+	for _, tt := range tests {
+		if got := EloExpected(tt.rating, tt.opponent); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("EloExpected(%v, %v) = %v, want %v", tt.rating, tt.opponent, got, tt.want)
+		}
+	}
+}
+
+func TestGoalDifferenceMultiplier(t *testing.T) {
+	for diff, want := range map[int]float64{0: 1, 1: 1, -2: 1.5, 3: 1.75, -5: 2} {
+		if got := goalDifferenceMultiplier(diff); got != want {
+			t.Errorf("goalDifferenceMultiplier(%d) = %v, want %v", diff, got, want)
+		}
+	}
+}
+
+func TestComputeElo(t *testing.T) {
+	fixtures := []GeneralFixtureData{
+		playedFixture(1, 1, 1, 2, 3, 0),
+		playedFixture(2, 8, 2, 1, 1, 1),
+		playedFixture(3, 15, 1, 2, -1, -1),
+	}
+	r := ComputeElo(fixtures, DefaultEloConfig())
+
+	// 3-0 home win: expected 0.592466 with 65 points of home advantage,
+	// K 20 and a 1.75 margin multiplier.
+	first := r.History(1)[0]
+	if math.Abs(first.Change-14.263682) > 1e-6 || math.Abs(first.Rating-1514.263682) > 1e-6 {
+		t.Fatalf("after the win = %+v", first)
+	}
+	// 1-1 draw with team 2 at home: expected 0.552296, no margin multiplier.
+	if got := r.Rating(1); math.Abs(got-1515.309610) > 1e-6 {
+		t.Fatalf("team 1 rating = %v, want 1515.309610", got)
+	}
+	if got := r.Rating(1) + r.Rating(2); math.Abs(got-3000) > 1e-9 {
+		t.Fatalf("ratings sum to %v, want 3000", got)
+	}
+
+	pre := r.PreMatch(fixtures[1])
+	if pre.HomeRating != r.History(2)[0].Rating || math.Abs(pre.HomeExpected-0.552296) > 1e-6 {
+		t.Fatalf("pre-match of the draw = %+v", pre)
+	}
+	if up := r.PreMatch(fixtures[2]); up.HomeRating != r.Rating(1) || up.AwayRating != r.Rating(2) {
+		t.Fatalf("pre-match of the upcoming fixture = %+v", up)
+	}
+	if got := r.RatingAt(1, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); got != 1500 {
+		t.Fatalf("rating before the first fixture = %v, want 1500", got)
+	}
+	if table := r.Table(); len(table) != 2 || table[0].TeamID != 1 || table[0].Matches != 2 {
+		t.Fatalf("table = %+v", table)
+	}
+}
+
+func TestEloRatingsZeroValue(t *testing.T) {
+	var r EloRatings
+	r.Config = DefaultEloConfig()
+	if _, ok := r.Update(playedFixture(1, 1, 1, 2, 1, 0)); !ok {
+		t.Fatal("played fixture not applied")
+	}
+	if r.Rating(1) <= 1500 || len(r.History(2)) != 1 {
+		t.Fatalf("ratings = %+v", r.Table())
+	}
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorCode identifies a class of failure in a stable, machine readable way.
+type ErrorCode string
+
+// Error codes returned by adapters and services.
+const (
+	CodeNotFound            ErrorCode = "not_found"
+	CodeInvalidRequest      ErrorCode = "invalid_request"
+	CodeQuotaExceeded       ErrorCode = "quota_exceeded"
+	CodeUpstreamUnavailable ErrorCode = "upstream_unavailable"
+	CodeDataInconsistent    ErrorCode = "data_inconsistent"
+	CodeInternal            ErrorCode = "internal"
+)
+
+// Sentinel errors for use with errors.Is. Any *Error matches the sentinel with
+// the same code; other *Error values only match themselves.
+var (
+	ErrNotFound            = &Error{Code: CodeNotFound, Message: "not found", matchCode: true}
+	ErrInvalidRequest      = &Error{Code: CodeInvalidRequest, Message: "invalid request", matchCode: true}
+	ErrQuotaExceeded       = &Error{Code: CodeQuotaExceeded, Message: "quota exceeded", matchCode: true}
+	ErrUpstreamUnavailable = &Error{Code: CodeUpstreamUnavailable, Message: "upstream unavailable", matchCode: true}
+	ErrDataInconsistent    = &Error{Code: CodeDataInconsistent, Message: "data inconsistent", matchCode: true}
+)
+
+// Error is the typed error returned by adapters and services.
+type Error struct {
+	Code    ErrorCode    `json:"code" bson:"code"`
+	Message string       `json:"message" bson:"message"`
+	Fields  []FieldError `json:"fields,omitempty" bson:"fields,omitempty"`
+	Err     error        `json:"-" bson:"-"`
+
+	// matchCode makes the error a sentinel matching every error with its code.
+	matchCode bool
+}
+
+// FieldError describes why a single request field is invalid.
+type FieldError struct {
+	Field   string `json:"field" bson:"field"`
+	Message string `json:"message" bson:"message"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := e.Message
+	if len(e.Fields) > 0 {
+		parts := make([]string, 0, len(e.Fields))
+		for _, f := range e.Fields {
+			parts = append(parts, f.Field+": "+f.Message)
+		}
+		msg += " (" + strings.Join(parts, "; ") + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is reports whether target is a code sentinel, such as ErrNotFound, with the same code.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.matchCode && t.Code == e.Code
+}
+
+// HTTPStatus returns the HTTP status code matching the error code.
+func (e *Error) HTTPStatus() int {
+	switch e.Code {
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeInvalidRequest:
+		return http.StatusBadRequest
+	case CodeQuotaExceeded:
+		return http.StatusTooManyRequests
+	case CodeUpstreamUnavailable:
+		return http.StatusBadGateway
+	case CodeDataInconsistent:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// NotFound returns a CodeNotFound error for the given kind of resource and id.
+func NotFound(resource, id string) *Error {
+	return &Error{Code: CodeNotFound, Message: fmt.Sprintf("%s %s not found", resource, id)}
+}
+
+// InvalidRequest returns a CodeInvalidRequest error listing the invalid fields.
+func InvalidRequest(fields ...FieldError) *Error {
+	return &Error{Code: CodeInvalidRequest, Message: "invalid request", Fields: fields}
+}
+
+// QuotaExceeded returns a CodeQuotaExceeded error.
+func QuotaExceeded(message string) *Error {
+	return &Error{Code: CodeQuotaExceeded, Message: message}
+}
+
+// UpstreamUnavailable returns a CodeUpstreamUnavailable error wrapping the upstream failure.
+func UpstreamUnavailable(err error) *Error {
+	return &Error{Code: CodeUpstreamUnavailable, Message: "upstream unavailable", Err: err}
+}
+
+// DataInconsistent returns a CodeDataInconsistent error.
+func DataInconsistent(format string, args ...any) *Error {
+	return &Error{Code: CodeDataInconsistent, Message: fmt.Sprintf(format, args...)}
+}
+
+// AsError returns err as an *Error, wrapping errors of other types as CodeInternal.
+func AsError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return &Error{Code: CodeInternal, Message: "internal error", Err: err}
+}
+
+// HTTPStatus returns the HTTP status code for any error, 500 for untyped errors
+// and 200 for nil.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return AsError(err).HTTPStatus()
+}
+
+// ErrorEnvelope is the stable JSON body of an error response.
+type ErrorEnvelope struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody is the error carried by an ErrorEnvelope. Internal causes are not exposed.
+type ErrorBody struct {
+	Code    ErrorCode    `json:"code" bson:"code"`
+	Message string       `json:"message" bson:"message"`
+	Fields  []FieldError `json:"fields,omitempty" bson:"fields,omitempty"`
+}
+
+// Body returns the envelope body of err.
+func (e *Error) Body() ErrorBody {
+	return ErrorBody{Code: e.Code, Message: e.Message, Fields: e.Fields}
+}
+
+// WriteError writes err as a JSON ErrorEnvelope with the matching HTTP status.
+// A nil err is written as an internal error, since there is nothing to report.
+func WriteError(w http.ResponseWriter, err error) {
+	e := AsError(err)
+	if e == nil {
+		e = &Error{Code: CodeInternal, Message: "internal error"}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.HTTPStatus())
+	_ = json.NewEncoder(w).Encode(ErrorEnvelope{Error: e.Body()})
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorIs(t *testing.T) {
+	perMinute := QuotaExceeded("per-minute limit reached")
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"code sentinel", NotFound("fixture", "1"), ErrNotFound, true},
+		{"wrapped", fmt.Errorf("load: %w", NotFound("fixture", "1")), ErrNotFound, true},
+		{"other code", NotFound("fixture", "1"), ErrInvalidRequest, false},
+		{"quota exceeded", perMinute, ErrQuotaExceeded, true},
+		{"per-minute is not exhausted", perMinute, ErrQuotaExhausted, false},
+		{"exhausted", fmt.Errorf("fetch: %w", ErrQuotaExhausted), ErrQuotaExhausted, true},
+		{"exhausted is exceeded", ErrQuotaExhausted, ErrQuotaExceeded, true},
+		{"constructed target", NotFound("fixture", "1"), NotFound("fixture", "1"), false},
+		{"untyped", errors.New("boom"), ErrNotFound, false},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(tt.err, tt.target); got != tt.want {
+			t.Errorf("%s: errors.Is = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStopReasonOnlyForDailyQuota(t *testing.T) {
+	ctx := context.Background()
+	if got := stopReason(ctx, QuotaExceeded("per-minute limit reached")); got != "" {
+		t.Errorf("per-minute quota stop reason = %q, want none", got)
+	}
+	if got := stopReason(ctx, fmt.Errorf("fixture 1: %w", ErrQuotaExhausted)); got != BackfillStoppedQuota {
+		t.Errorf("daily quota stop reason = %q, want %q", got, BackfillStoppedQuota)
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{NotFound("league", "39"), http.StatusNotFound},
+		{InvalidRequest(FieldError{Field: "season"}), http.StatusBadRequest},
+		{ErrQuotaExhausted, http.StatusTooManyRequests},
+		{UpstreamUnavailable(errors.New("timeout")), http.StatusBadGateway},
+		{DataInconsistent("bad"), http.StatusUnprocessableEntity},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.err); got != tt.want {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   ErrorCode
+	}{
+		{InvalidRequest(FieldError{Field: "season", Message: "required"}), http.StatusBadRequest, CodeInvalidRequest},
+		{fmt.Errorf("secret cause"), http.StatusInternalServerError, CodeInternal},
+		{nil, http.StatusInternalServerError, CodeInternal},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		WriteError(w, tt.err)
+		var envelope ErrorEnvelope
+		if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != tt.status || envelope.Error.Code != tt.code {
+			t.Errorf("WriteError(%v) = %d %+v, want %d %s", tt.err, w.Code, envelope.Error, tt.status, tt.code)
+		}
+		if envelope.Error.Message == "secret cause" {
+			t.Errorf("WriteError exposed the internal cause")
+		}
+	}
+}
@@ -5,9 +5,12 @@ import (
 )
 
 // GetFixturesByDateRequest represents the request body for fetching fixtures by date.
+// Date is a calendar day in Timezone, which is required so that a league's
+// matchday is not silently read as a UTC day; pass "UTC" for UTC days.
 type GetFixturesByDateAndLeagueRequest struct {
-	Date   string `json:"date"`
-	League string `json:"league"`
+	Date     string `json:"date"`
+	League   string `json:"league"`
+	Timezone string `json:"timezone"`
 }
 
 // FixtureRequest represents the request body fora specific fixture.
@@ -21,9 +24,11 @@ type FixtureRequest struct {
 type GeneralFixtureData struct {
 	StandingsData StandingsData  `json:"standings_data" bson:"standings"`
 	FixtureID     string         `json:"fixture_id" bson:"fixture_id"`
+	LeagueID      string         `json:"league_id" bson:"league_id"`
 	FixtureData   FixtureData    `json:"fixture_data" bson:"current_data"`
 	HomeTeamStats TeamStatistics `json:"home_team_stats" bson:"home_form_data"`
 	AwayTeamStats TeamStatistics `json:"away_team_stats" bson:"away_form_data"`
+	HeadToHead    *HeadToHead    `json:"head_to_head,omitempty" bson:"head_to_head,omitempty"`
 }
 
 // FixtureData holds specific details about a match including the participating teams, venue,
@@ -0,0 +1,818 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query is a parsed fixture query such as
+//
+//	league=39 AND status IN (FT,AET) AND date>=2024-08-01 AND team_id=33 ORDER BY date DESC LIMIT 10
+//
+// Where is nil when the query has no condition. A zero Limit means no limit.
+type Query struct {
+	Where   Expr
+	OrderBy []OrderTerm
+	Limit   int
+}
+
+// OrderTerm sorts query results by a single field.
+type OrderTerm struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
+}
+
+// Expr is a node of a parsed query condition. Repository implementations
+// translate a query by switching over the concrete node types.
+type Expr interface {
+	Match(fixture GeneralFixtureData) bool
+	String() string
+}
+
+// AndExpr matches when every term matches.
+type AndExpr struct {
+	Terms []Expr
+}
+
+// OrExpr matches when any term matches.
+type OrExpr struct {
+	Terms []Expr
+}
+
+// NotExpr matches when its term does not.
+type NotExpr struct {
+	Term Expr
+}
+
+// CompareExpr compares a fixture field with one or more values. Op is one of
+// =, !=, <, <=, >, >=, IN and NOT IN. Values hold the literals as written;
+// they are parsed by the field's type when the expression is evaluated, so
+// expressions may also be built by hand. Literals that do not parse are ignored.
+type CompareExpr struct {
+	Field  string
+	Op     string
+	Values []string
+
+	parsed []any
+}
+
+// Query comparison operators.
+const (
+	OpEq    = "="
+	OpNe    = "!="
+	OpLt    = "<"
+	OpLe    = "<="
+	OpGt    = ">"
+	OpGe    = ">="
+	OpIn    = "IN"
+	OpNotIn = "NOT IN"
+)
+
+type queryKind int
+
+const (
+	queryString queryKind = iota
+	queryInt
+	queryTime
+	queryBool
+)
+
+// queryField describes a field usable in queries: its value type, the bson
+// path it is stored under and how to read it from a fixture.
+type queryField struct {
+	kind queryKind
+	path string
+	get  func(GeneralFixtureData) any
+}
+
+// queryFields lists the queryable fields. team_id is virtual and matches
+// either side of the fixture.
+var queryFields = map[string]queryField{
+	"fixture_id":   {queryString, "fixture_id", func(f GeneralFixtureData) any { return f.FixtureID }},
+	"league":       {queryString, "league_id", func(f GeneralFixtureData) any { return f.LeagueID }},
+	"league_name":  {queryString, "current_data.league_name", func(f GeneralFixtureData) any { return f.FixtureData.LeagueName }},
+	"country":      {queryString, "current_data.league_country", func(f GeneralFixtureData) any { return f.FixtureData.LeagueCountry }},
+	"round":        {queryString, "current_data.league_round", func(f GeneralFixtureData) any { return f.FixtureData.LeagueRound }},
+	"status":       {queryString, "current_data.game_status", func(f GeneralFixtureData) any { return f.FixtureData.GameStatus }},
+	"date":         {queryTime, "current_data.date", func(f GeneralFixtureData) any { return f.FixtureData.Date }},
+	"referee":      {queryString, "current_data.referee", func(f GeneralFixtureData) any { return f.FixtureData.Referee }},
+	"venue":        {queryString, "current_data.venue", func(f GeneralFixtureData) any { return f.FixtureData.Venue }},
+	"winner":       {queryString, "current_data.winner", func(f GeneralFixtureData) any { return f.FixtureData.Winner }},
+	"home_team":    {queryString, "current_data.home_team", func(f GeneralFixtureData) any { return f.FixtureData.HomeTeam }},
+	"away_team":    {queryString, "current_data.away_team", func(f GeneralFixtureData) any { return f.FixtureData.AwayTeam }},
+	"home_team_id": {queryInt, "current_data.home_team_id", func(f GeneralFixtureData) any { return f.FixtureData.HomeTeamID }},
+	"away_team_id": {queryInt, "current_data.away_team_id", func(f GeneralFixtureData) any { return f.FixtureData.AwayTeamID }},
+	"team_id":      {queryInt, "", nil},
+	"goals_home":   {queryInt, "current_data.goals_home", func(f GeneralFixtureData) any { return f.FixtureData.GoalsHome }},
+	"goals_away":   {queryInt, "current_data.goals_away", func(f GeneralFixtureData) any { return f.FixtureData.GoalsAway }},
+	"finished":     {queryBool, "current_data.finished", func(f GeneralFixtureData) any { return f.FixtureData.Finished }},
+}
+
+// queryDay marks a date literal without a time of day; comparisons against it
+// cover the whole UTC day.
+type queryDay struct{ time.Time }
+
+// ParseQuery parses a fixture query. Syntax errors are returned as InvalidRequest errors.
+func ParseQuery(input string) (*Query, error) {
+	tokens, err := lexQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens}
+	q, err := p.query()
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Match reports whether the fixture satisfies the query condition.
+func (q *Query) Match(fixture GeneralFixtureData) bool {
+	return q.Where == nil || q.Where.Match(fixture)
+}
+
+// Apply filters, sorts and limits fixtures in memory. The input slice is not modified.
+func (q *Query) Apply(fixtures []GeneralFixtureData) []GeneralFixtureData {
+	var out []GeneralFixtureData
+	for _, f := range fixtures {
+		if q.Match(f) {
+			out = append(out, f)
+		}
+	}
+	if len(q.OrderBy) > 0 {
+		sort.SliceStable(out, func(i, j int) bool {
+			for _, term := range q.OrderBy {
+				field := queryFields[term.Field]
+				c := compareQueryValues(field.get(out[i]), field.get(out[j]))
+				if c == 0 {
+					continue
+				}
+				if term.Desc {
+					return c > 0
+				}
+				return c < 0
+			}
+			return false
+		})
+	}
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[:q.Limit]
+	}
+	return out
+}
+
+// Filter returns a FixtureFilter selecting a superset of the fixtures the query
+// matches, built from the league, status, team_id and date conditions joined
+// by AND at the top level. It lets a repository narrow the candidates before
+// the full query is applied.
+func (q *Query) Filter() FixtureFilter {
+	var filter FixtureFilter
+	var terms []Expr
+	switch w := q.Where.(type) {
+	case *AndExpr:
+		terms = w.Terms
+	case nil:
+	default:
+		terms = []Expr{w}
+	}
+
+	for _, t := range terms {
+		c, ok := t.(*CompareExpr)
+		if !ok {
+			continue
+		}
+		switch {
+		case c.Field == "league" && (c.Op == OpEq || c.Op == OpIn) && filter.LeagueIDs == nil:
+			filter.LeagueIDs = append([]string{}, c.Values...)
+		case c.Field == "status" && (c.Op == OpEq || c.Op == OpIn) && filter.Statuses == nil:
+			filter.Statuses = append([]string{}, c.Values...)
+		case c.Field == "team_id" && (c.Op == OpEq || c.Op == OpIn) && filter.TeamIDs == nil:
+			filter.TeamIDs = []int{}
+			for _, v := range c.values() {
+				filter.TeamIDs = append(filter.TeamIDs, v.(int))
+			}
+		case c.Field == "date":
+			from, to := timeBounds(c)
+			if !from.IsZero() && from.After(filter.From) {
+				filter.From = from
+			}
+			if !to.IsZero() && (filter.To.IsZero() || to.Before(filter.To)) {
+				filter.To = to
+			}
+		}
+	}
+	return filter
+}
+
+// Find runs the query against a repository: candidates are narrowed with
+// Filter and the full query is then applied to them.
+func (q *Query) Find(ctx context.Context, finder FixtureFinder) ([]GeneralFixtureData, error) {
+	candidates, err := finder.FindFixtures(ctx, q.Filter())
+	if err != nil {
+		return nil, err
+	}
+	return q.Apply(candidates), nil
+}
+
+// MongoFilter translates the query condition into a MongoDB filter document
+// over the bson layout of GeneralFixtureData.
+func (q *Query) MongoFilter() map[string]any {
+	if q.Where == nil {
+		return map[string]any{}
+	}
+	return mongoExpr(q.Where)
+}
+
+// MongoSort translates the ordering into MongoDB sort keys, in order.
+func (q *Query) MongoSort() []MongoSortKey {
+	out := make([]MongoSortKey, 0, len(q.OrderBy))
+	for _, t := range q.OrderBy {
+		dir := 1
+		if t.Desc {
+			dir = -1
+		}
+		out = append(out, MongoSortKey{Key: queryFields[t.Field].path, Direction: dir})
+	}
+	return out
+}
+
+// MongoSortKey is a single MongoDB sort key; Direction is 1 or -1.
+type MongoSortKey struct {
+	Key       string `json:"key"`
+	Direction int    `json:"direction"`
+}
+
+// Match implements Expr.
+func (e *AndExpr) Match(f GeneralFixtureData) bool {
+	for _, t := range e.Terms {
+		if !t.Match(f) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *AndExpr) String() string { return joinExprs(e.Terms, " AND ") }
+
+// Match implements Expr.
+func (e *OrExpr) Match(f GeneralFixtureData) bool {
+	for _, t := range e.Terms {
+		if t.Match(f) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *OrExpr) String() string { return joinExprs(e.Terms, " OR ") }
+
+// Match implements Expr.
+func (e *NotExpr) Match(f GeneralFixtureData) bool { return !e.Term.Match(f) }
+
+func (e *NotExpr) String() string { return "NOT (" + e.Term.String() + ")" }
+
+// Match implements Expr.
+func (e *CompareExpr) Match(f GeneralFixtureData) bool {
+	field, ok := queryFields[e.Field]
+	if !ok {
+		return false
+	}
+	if e.Field == "team_id" {
+		home, away := e.matchValue(f.FixtureData.HomeTeamID), e.matchValue(f.FixtureData.AwayTeamID)
+		if e.Op == OpNe || e.Op == OpNotIn {
+			return home && away
+		}
+		return home || away
+	}
+	return e.matchValue(field.get(f))
+}
+
+func (e *CompareExpr) matchValue(actual any) bool {
+	values := e.values()
+	switch e.Op {
+	case OpIn, OpEq:
+		for _, v := range values {
+			if compareQueryValues(actual, v) == 0 {
+				return true
+			}
+		}
+		return false
+	case OpNotIn, OpNe:
+		for _, v := range values {
+			if compareQueryValues(actual, v) == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if len(values) == 0 {
+		return false
+	}
+
+	c := compareQueryValues(actual, values[0])
+	switch e.Op {
+	case OpLt:
+		return c < 0
+	case OpLe:
+		return c <= 0
+	case OpGt:
+		return c > 0
+	case OpGe:
+		return c >= 0
+	}
+	return false
+}
+
+func (e *CompareExpr) String() string {
+	if e.Op == OpIn || e.Op == OpNotIn {
+		return e.Field + " " + e.Op + " (" + strings.Join(e.Values, ",") + ")"
+	}
+	return e.Field + e.Op + strings.Join(e.Values, ",")
+}
+
+// values returns the literals parsed by the field's type. Parsed queries
+// carry them already; hand-built expressions are parsed on every call so
+// that evaluating a shared query stays free of writes.
+func (e *CompareExpr) values() []any {
+	if e.parsed != nil {
+		return e.parsed
+	}
+	kind := queryFields[e.Field].kind
+	out := make([]any, 0, len(e.Values))
+	for _, raw := range e.Values {
+		if v, err := parseQueryValue(kind, raw); err == nil {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func joinExprs(terms []Expr, sep string) string {
+	parts := make([]string, len(terms))
+	for i, t := range terms {
+		parts[i] = t.String()
+		if _, ok := t.(*CompareExpr); !ok {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// compareQueryValues orders an actual field value against a literal or another
+// field value of the same kind. Day literals compare equal to any time within the day.
+func compareQueryValues(a, b any) int {
+	switch av := a.(type) {
+	case string:
+		return strings.Compare(av, b.(string))
+	case int:
+		bv := b.(int)
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+		return 0
+	case bool:
+		bv := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case !av:
+			return -1
+		}
+		return 1
+	case time.Time:
+		switch bv := b.(type) {
+		case queryDay:
+			switch {
+			case av.Before(bv.Time):
+				return -1
+			case !av.Before(bv.AddDate(0, 0, 1)):
+				return 1
+			}
+			return 0
+		case time.Time:
+			return av.Compare(bv)
+		}
+	}
+	return 0
+}
+
+// timeBounds returns the [from, to) kickoff range implied by a date comparison.
+func timeBounds(c *CompareExpr) (time.Time, time.Time) {
+	values := c.values()
+	if len(values) == 0 || c.Op != OpEq && c.Op != OpLt && c.Op != OpLe && c.Op != OpGt && c.Op != OpGe {
+		return time.Time{}, time.Time{}
+	}
+	start, end := literalRange(values[0])
+	switch c.Op {
+	case OpEq:
+		return start, end
+	case OpLt:
+		return time.Time{}, start
+	case OpLe:
+		return time.Time{}, end
+	case OpGt:
+		return end, time.Time{}
+	default:
+		return start, time.Time{}
+	}
+}
+
+// literalRange returns the [start, end) instants covered by a time literal.
+func literalRange(v any) (time.Time, time.Time) {
+	if d, ok := v.(queryDay); ok {
+		return d.Time, d.AddDate(0, 0, 1)
+	}
+	t := v.(time.Time)
+	return t, t.Add(time.Nanosecond)
+}
+
+func mongoExpr(e Expr) map[string]any {
+	switch e := e.(type) {
+	case *AndExpr:
+		return map[string]any{"$and": mongoExprs(e.Terms)}
+	case *OrExpr:
+		return map[string]any{"$or": mongoExprs(e.Terms)}
+	case *NotExpr:
+		return map[string]any{"$nor": []map[string]any{mongoExpr(e.Term)}}
+	case *CompareExpr:
+		if e.Field == "team_id" {
+			home := *e
+			home.Field = "home_team_id"
+			away := *e
+			away.Field = "away_team_id"
+			op := "$or"
+			if e.Op == OpNe || e.Op == OpNotIn {
+				op = "$and"
+			}
+			return map[string]any{op: []map[string]any{mongoExpr(&home), mongoExpr(&away)}}
+		}
+		return mongoCompare(e)
+	}
+	return map[string]any{}
+}
+
+func mongoExprs(terms []Expr) []map[string]any {
+	out := make([]map[string]any, len(terms))
+	for i, t := range terms {
+		out[i] = mongoExpr(t)
+	}
+	return out
+}
+
+func mongoCompare(e *CompareExpr) map[string]any {
+	path := queryFields[e.Field].path
+	values := e.values()
+	if len(values) == 0 {
+		// Without a usable literal nothing is equal or ordered, as in Match.
+		if e.Op == OpNe || e.Op == OpNotIn {
+			return map[string]any{}
+		}
+		return map[string]any{path: map[string]any{"$in": []any{}}}
+	}
+	if queryFields[e.Field].kind == queryTime {
+		return mongoTimeCompare(path, e.Op, values)
+	}
+
+	ops := map[string]string{OpEq: "$eq", OpNe: "$ne", OpLt: "$lt", OpLe: "$lte", OpGt: "$gt", OpGe: "$gte"}
+	switch e.Op {
+	case OpIn:
+		return map[string]any{path: map[string]any{"$in": values}}
+	case OpNotIn:
+		return map[string]any{path: map[string]any{"$nin": values}}
+	}
+	return map[string]any{path: map[string]any{ops[e.Op]: values[0]}}
+}
+
+func mongoTimeCompare(path, op string, values []any) map[string]any {
+	ranges := make([]map[string]any, 0, len(values))
+	for _, v := range values {
+		start, end := literalRange(v)
+		ranges = append(ranges, map[string]any{path: map[string]any{"$gte": start, "$lt": end}})
+	}
+
+	switch op {
+	case OpEq:
+		return ranges[0]
+	case OpIn:
+		return map[string]any{"$or": ranges}
+	case OpNe, OpNotIn:
+		return map[string]any{"$nor": ranges}
+	}
+
+	start, end := literalRange(values[0])
+	switch op {
+	case OpLt:
+		return map[string]any{path: map[string]any{"$lt": start}}
+	case OpLe:
+		return map[string]any{path: map[string]any{"$lt": end}}
+	case OpGt:
+		return map[string]any{path: map[string]any{"$gte": end}}
+	default:
+		return map[string]any{path: map[string]any{"$gte": start}}
+	}
+}
+
+type queryTokenKind int
+
+const (
+	tokWord queryTokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+	tokEOF
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+func lexQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{tokRParen, ")", i})
+			i++
+		case c == ',':
+			tokens = append(tokens, queryToken{tokComma, ",", i})
+			i++
+		case c == '=' || c == '<' || c == '>' || c == '!':
+			op := string(c)
+			if i+1 < len(input) && input[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, queryError(i, "expected != but found !")
+			}
+			tokens = append(tokens, queryToken{tokOp, op, i})
+			i += len(op)
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(input[i+1:], c)
+			if end < 0 {
+				return nil, queryError(i, "unterminated string")
+			}
+			tokens = append(tokens, queryToken{tokString, input[i+1 : i+1+end], i})
+			i += end + 2
+		case isQueryWordByte(c):
+			start := i
+			for i < len(input) && isQueryWordByte(input[i]) {
+				i++
+			}
+			tokens = append(tokens, queryToken{tokWord, input[start:i], start})
+		default:
+			return nil, queryError(i, fmt.Sprintf("unexpected character %q", c))
+		}
+	}
+	return append(tokens, queryToken{tokEOF, "", len(input)}), nil
+}
+
+func isQueryWordByte(c byte) bool {
+	return c < unicode.MaxASCII && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))) ||
+		c == '_' || c == '-' || c == ':' || c == '.' || c == '+'
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken { return p.tokens[p.pos] }
+
+func (p *queryParser) next() queryToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the given case-insensitive keyword, consuming it if so.
+func (p *queryParser) keyword(kw string) bool {
+	t := p.peek()
+	if t.kind == tokWord && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == tokWord && strings.EqualFold(t.text, kw)
+}
+
+func (p *queryParser) query() (*Query, error) {
+	q := &Query{}
+	if !p.isKeyword("ORDER") && !p.isKeyword("LIMIT") && p.peek().kind != tokEOF {
+		where, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		q.Where = where
+	}
+
+	if p.keyword("ORDER") {
+		if !p.keyword("BY") {
+			return nil, queryError(p.peek().pos, "expected BY after ORDER")
+		}
+		for {
+			t := p.next()
+			if t.kind != tokWord {
+				return nil, queryError(t.pos, "expected field name")
+			}
+			field := strings.ToLower(t.text)
+			if f, ok := queryFields[field]; !ok || f.get == nil {
+				return nil, queryError(t.pos, fmt.Sprintf("cannot order by %q", t.text))
+			}
+			term := OrderTerm{Field: field}
+			if p.keyword("DESC") {
+				term.Desc = true
+			} else {
+				p.keyword("ASC")
+			}
+			q.OrderBy = append(q.OrderBy, term)
+			if p.peek().kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if p.keyword("LIMIT") {
+		t := p.next()
+		n, err := strconv.Atoi(t.text)
+		if t.kind != tokWord || err != nil || n <= 0 {
+			return nil, queryError(t.pos, "LIMIT expects a positive integer")
+		}
+		q.Limit = n
+	}
+
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, queryError(t.pos, fmt.Sprintf("unexpected %q", t.text))
+	}
+	return q, nil
+}
+
+func (p *queryParser) or() (Expr, error) {
+	first, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	terms := []Expr{first}
+	for p.keyword("OR") {
+		next, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, next)
+	}
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return &OrExpr{Terms: terms}, nil
+}
+
+func (p *queryParser) and() (Expr, error) {
+	first, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	terms := []Expr{first}
+	for p.keyword("AND") {
+		next, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, next)
+	}
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return &AndExpr{Terms: terms}, nil
+}
+
+func (p *queryParser) unary() (Expr, error) {
+	if p.keyword("NOT") {
+		term, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Term: term}, nil
+	}
+	if p.peek().kind == tokLParen {
+		p.next()
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, queryError(t.pos, "expected )")
+		}
+		return e, nil
+	}
+	return p.comparison()
+}
+
+func (p *queryParser) comparison() (Expr, error) {
+	t := p.next()
+	if t.kind != tokWord {
+		return nil, queryError(t.pos, "expected field name")
+	}
+	name := strings.ToLower(t.text)
+	if name == "league_id" {
+		name = "league"
+	}
+	field, ok := queryFields[name]
+	if !ok {
+		return nil, queryError(t.pos, fmt.Sprintf("unknown field %q", t.text))
+	}
+
+	c := &CompareExpr{Field: name}
+	switch {
+	case p.peek().kind == tokOp:
+		c.Op = p.next().text
+		v := p.next()
+		if v.kind != tokWord && v.kind != tokString {
+			return nil, queryError(v.pos, "expected value")
+		}
+		c.Values = []string{v.text}
+	case p.keyword("IN"):
+		c.Op = OpIn
+	case p.keyword("NOT"):
+		if !p.keyword("IN") {
+			return nil, queryError(p.peek().pos, "expected IN after NOT")
+		}
+		c.Op = OpNotIn
+	default:
+		return nil, queryError(p.peek().pos, "expected comparison operator")
+	}
+
+	if c.Op == OpIn || c.Op == OpNotIn {
+		if t := p.next(); t.kind != tokLParen {
+			return nil, queryError(t.pos, "expected ( after IN")
+		}
+		for {
+			v := p.next()
+			if v.kind != tokWord && v.kind != tokString {
+				return nil, queryError(v.pos, "expected value")
+			}
+			c.Values = append(c.Values, v.text)
+			sep := p.next()
+			if sep.kind == tokRParen {
+				break
+			}
+			if sep.kind != tokComma {
+				return nil, queryError(sep.pos, "expected , or )")
+			}
+		}
+	}
+
+	if field.kind == queryBool && c.Op != OpEq && c.Op != OpNe {
+		return nil, queryError(t.pos, fmt.Sprintf("%s only supports = and !=", name))
+	}
+	for _, raw := range c.Values {
+		v, err := parseQueryValue(field.kind, raw)
+		if err != nil {
+			return nil, queryError(t.pos, fmt.Sprintf("invalid value %q for %s: %v", raw, name, err))
+		}
+		c.parsed = append(c.parsed, v)
+	}
+	return c, nil
+}
+
+func parseQueryValue(kind queryKind, raw string) (any, error) {
+	switch kind {
+	case queryInt:
+		return strconv.Atoi(raw)
+	case queryBool:
+		return strconv.ParseBool(raw)
+	case queryTime:
+		if d, err := time.Parse(fixtureDateLayout, raw); err == nil {
+			return queryDay{d}, nil
+		}
+		return time.Parse(time.RFC3339, raw)
+	}
+	return raw, nil
+}
+
+func queryError(pos int, msg string) error {
+	return InvalidRequest(FieldError{Field: "query", Message: fmt.Sprintf("at offset %d: %s", pos, msg)})
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func queryFixture(id, league, status string, day, home, away int) GeneralFixtureData {
+	return GeneralFixtureData{FixtureID: id, LeagueID: league, FixtureData: FixtureData{
+		Date:       time.Date(2024, 8, day, 15, 0, 0, 0, time.UTC),
+		GameStatus: status,
+		HomeTeamID: home,
+		AwayTeamID: away,
+		Finished:   status == "FT",
+	}}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		input string
+		where string
+		ok    bool
+	}{
+		{"league=39", "league=39", true},
+		{"league_id = 39 AND status IN (FT, AET)", "league=39 AND status IN (FT,AET)", true},
+		{"NOT (finished=true) OR team_id!=33", "(NOT (finished=true)) OR team_id!=33", true},
+		{"date>=2024-08-01 ORDER BY date DESC LIMIT 5", "date>=2024-08-01", true},
+		{"foo=1", "", false},
+		{"league IN (39", "", false},
+		{"team_id=abc", "", false},
+		{"finished>true", "", false},
+		{"status NOT FT", "", false},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.input)
+		if !tt.ok {
+			if !errors.Is(err, ErrInvalidRequest) {
+				t.Errorf("ParseQuery(%q) = %v, want an invalid request", tt.input, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseQuery(%q) = %v", tt.input, err)
+			continue
+		}
+		if got := q.Where.String(); got != tt.where {
+			t.Errorf("ParseQuery(%q) = %s, want %s", tt.input, got, tt.where)
+		}
+	}
+
+	q, err := ParseQuery("league=39 ORDER BY date DESC, fixture_id LIMIT 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Limit != 5 || len(q.OrderBy) != 2 || q.OrderBy[0] != (OrderTerm{Field: "date", Desc: true}) || q.OrderBy[1] != (OrderTerm{Field: "fixture_id"}) {
+		t.Fatalf("query = %+v", q)
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	f := queryFixture("1", "39", "FT", 1, 33, 40)
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"league=39", true},
+		{"league IN (140,39)", true},
+		{"league NOT IN (140,39)", false},
+		{"team_id=40", true},
+		{"team_id!=40", false},
+		{"team_id NOT IN (1,2)", true},
+		{"date=2024-08-01", true},
+		{"date>2024-08-01", false},
+		{"date<=2024-08-01", true},
+		{"date<2024-08-01T15:00:00Z", false},
+		{"goals_home>=0 AND finished=true", true},
+		{"status=NS OR home_team_id=33", true},
+		{"NOT (status=FT)", false},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := q.Match(f); got != tt.want {
+			t.Errorf("%s matched = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCompareExprBuiltByHand(t *testing.T) {
+	f := queryFixture("1", "39", "FT", 1, 33, 40)
+	tests := []struct {
+		expr  *CompareExpr
+		want  bool
+		mongo string
+	}{
+		{&CompareExpr{Field: "team_id", Op: OpIn, Values: []string{"33"}}, true,
+			`{"$or":[{"current_data.home_team_id":{"$in":[33]}},{"current_data.away_team_id":{"$in":[33]}}]}`},
+		{&CompareExpr{Field: "goals_home", Op: OpGt, Values: []string{"x"}}, false,
+			`{"current_data.goals_home":{"$in":[]}}`},
+		{&CompareExpr{Field: "league", Op: OpNe}, true, `{}`},
+		{&CompareExpr{Field: "date", Op: OpLt, Values: []string{"2024-08-02"}}, true,
+			`{"current_data.date":{"$lt":"2024-08-02T00:00:00Z"}}`},
+		{&CompareExpr{Field: "unknown", Op: OpEq, Values: []string{"1"}}, false, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.expr.Match(f); got != tt.want {
+			t.Errorf("%s matched = %v, want %v", tt.expr, got, tt.want)
+		}
+		if tt.mongo == "" {
+			continue
+		}
+		got, err := json.Marshal((&Query{Where: tt.expr}).MongoFilter())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.mongo {
+			t.Errorf("%s mongo = %s, want %s", tt.expr, got, tt.mongo)
+		}
+	}
+}
+
+func TestQueryMongoFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"league=39", `{"league_id":{"$eq":"39"}}`},
+		{"status NOT IN (FT,AET)", `{"current_data.game_status":{"$nin":["FT","AET"]}}`},
+		{"team_id!=33", `{"$and":[{"current_data.home_team_id":{"$ne":33}},{"current_data.away_team_id":{"$ne":33}}]}`},
+		{"date=2024-08-01", `{"current_data.date":{"$gte":"2024-08-01T00:00:00Z","$lt":"2024-08-02T00:00:00Z"}}`},
+		{"date>2024-08-01", `{"current_data.date":{"$gte":"2024-08-02T00:00:00Z"}}`},
+		{"league=39 AND NOT finished=true", `{"$and":[{"league_id":{"$eq":"39"}},{"$nor":[{"current_data.finished":{"$eq":true}}]}]}`},
+	}
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := json.Marshal(q.MongoFilter())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s mongo = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestQueryApplyAndFilter(t *testing.T) {
+	fixtures := []GeneralFixtureData{
+		queryFixture("1", "39", "FT", 1, 33, 40),
+		queryFixture("2", "39", "FT", 3, 41, 33),
+		queryFixture("3", "39", "NS", 5, 33, 42),
+		queryFixture("4", "140", "FT", 2, 33, 43),
+	}
+	q, err := ParseQuery("league=39 AND team_id=33 AND date>=2024-08-02 ORDER BY date DESC LIMIT 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Apply(fixtures); len(got) != 1 || got[0].FixtureID != "3" {
+		t.Fatalf("Apply = %+v, want fixture 3", got)
+	}
+	filter := q.Filter()
+	if len(filter.LeagueIDs) != 1 || len(filter.TeamIDs) != 1 || filter.TeamIDs[0] != 33 ||
+		!filter.From.Equal(time.Date(2024, 8, 2, 0, 0, 0, 0, time.UTC)) || !filter.To.IsZero() {
+		t.Fatalf("Filter = %+v", filter)
+	}
+}
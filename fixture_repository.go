@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FixtureRepository stores GeneralFixtureData keyed by FixtureID.
+type FixtureRepository interface {
+	GetFixture(ctx context.Context, fixtureID string) (GeneralFixtureData, bool, error)
+	UpsertFixture(ctx context.Context, fixture GeneralFixtureData) error
+}
+
+// FixtureFinder finds stored fixtures matching a filter, ordered by kickoff
+// date and then fixture id, and returns at most the filter's Limit.
+type FixtureFinder interface {
+	FindFixtures(ctx context.Context, filter FixtureFilter) ([]GeneralFixtureData, error)
+}
+
+// FixtureFilter selects fixtures by league, kickoff range, team and status.
+// Empty fields do not restrict the selection; From is inclusive and To exclusive.
+// A non-empty AfterID only selects fixtures sorting strictly after the
+// (AfterDate, AfterID) key, which lets repositories page with a keyset cursor.
+// Limit caps the number of fixtures found, zero meaning no limit.
+type FixtureFilter struct {
+	LeagueIDs []string  `json:"league_ids"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	TeamIDs   []int     `json:"team_ids"`
+	Statuses  []string  `json:"statuses"`
+	AfterDate time.Time `json:"after_date"`
+	AfterID   string    `json:"after_id"`
+	Limit     int       `json:"limit"`
+}
+
+// Match reports whether the fixture satisfies the filter.
+func (f FixtureFilter) Match(fixture GeneralFixtureData) bool {
+	data := fixture.FixtureData
+	if len(f.LeagueIDs) > 0 && !containsString(f.LeagueIDs, fixture.LeagueID) {
+		return false
+	}
+	if !f.From.IsZero() && data.Date.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !data.Date.Before(f.To) {
+		return false
+	}
+	if len(f.TeamIDs) > 0 && !containsInt(f.TeamIDs, data.HomeTeamID) && !containsInt(f.TeamIDs, data.AwayTeamID) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !containsString(f.Statuses, data.GameStatus) {
+		return false
+	}
+	if f.AfterID != "" && !fixtureAfter(fixture, f.AfterDate, f.AfterID) {
+		return false
+	}
+	return true
+}
+
+// MemoryFixtureRepository is an in-memory FixtureRepository, useful for tests and small deployments.
+type MemoryFixtureRepository struct {
+	mu       sync.RWMutex
+	fixtures map[string]GeneralFixtureData
+}
+
+// NewMemoryFixtureRepository returns a MemoryFixtureRepository holding the given fixtures.
+func NewMemoryFixtureRepository(fixtures ...GeneralFixtureData) *MemoryFixtureRepository {
+	r := &MemoryFixtureRepository{fixtures: make(map[string]GeneralFixtureData, len(fixtures))}
+	for _, f := range fixtures {
+		r.fixtures[f.FixtureID] = f
+	}
+	return r
+}
+
+// GetFixture returns the fixture with the given id and whether it exists.
+func (r *MemoryFixtureRepository) GetFixture(_ context.Context, fixtureID string) (GeneralFixtureData, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	f, ok := r.fixtures[fixtureID]
+	return f, ok, nil
+}
+
+// UpsertFixture inserts the fixture or replaces the stored one with the same id.
+func (r *MemoryFixtureRepository) UpsertFixture(_ context.Context, fixture GeneralFixtureData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fixtures == nil {
+		r.fixtures = make(map[string]GeneralFixtureData)
+	}
+	r.fixtures[fixture.FixtureID] = fixture
+	return nil
+}
+
+// FindFixtures returns the stored fixtures matching filter ordered by kickoff date and then fixture id.
+func (r *MemoryFixtureRepository) FindFixtures(_ context.Context, filter FixtureFilter) ([]GeneralFixtureData, error) {
+	var out []GeneralFixtureData
+	for _, f := range r.All() {
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+		if filter.Match(f) {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// All returns every stored fixture ordered by kickoff date and then fixture id.
+func (r *MemoryFixtureRepository) All() []GeneralFixtureData {
+	r.mu.RLock()
+	out := make([]GeneralFixtureData, 0, len(r.fixtures))
+	for _, f := range r.fixtures {
+		out = append(out, f)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		di, dj := out[i].FixtureData.Date, out[j].FixtureData.Date
+		if di.Equal(dj) {
+			return out[i].FixtureID < out[j].FixtureID
+		}
+		return di.Before(dj)
+	})
+	return out
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, v int) bool {
+	for _, n := range values {
+		if n == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Fixture statuses as reported by API-Football in FixtureData.GameStatus.
+const (
+	StatusTimeToBeDefined = "TBD"
+	StatusNotStarted      = "NS"
+	StatusFirstHalf       = "1H"
+	StatusHalfTime        = "HT"
+	StatusSecondHalf      = "2H"
+	StatusExtraTime       = "ET"
+	StatusBreakTime       = "BT"
+	StatusPenalties       = "P"
+	StatusSuspended       = "SUSP"
+	StatusInterrupted     = "INT"
+	StatusFinished        = "FT"
+	StatusAfterExtraTime  = "AET"
+	StatusAfterPenalties  = "PEN"
+	StatusPostponed       = "PST"
+	StatusCancelled       = "CANC"
+	StatusAbandoned       = "ABD"
+	StatusAwarded         = "AWD"
+	StatusWalkover        = "WO"
+	StatusLive            = "LIVE"
+)
+
+// IsInPlay reports whether the ball is in play for the given status.
+func IsInPlay(status string) bool {
+	switch status {
+	case StatusFirstHalf, StatusSecondHalf, StatusExtraTime, StatusPenalties, StatusLive:
+		return true
+	}
+	return false
+}
+
+// IsBreak reports whether the fixture is live but paused for the given status.
+func IsBreak(status string) bool {
+	switch status {
+	case StatusHalfTime, StatusBreakTime, StatusSuspended, StatusInterrupted:
+		return true
+	}
+	return false
+}
+
+// IsLive reports whether the fixture has kicked off and not yet ended.
+func IsLive(status string) bool {
+	return IsInPlay(status) || IsBreak(status)
+}
+
+// IsFinal reports whether no further updates are expected for the given
+// status. Abandoned fixtures are not final as they await a replay or an
+// awarded result.
+func IsFinal(status string) bool {
+	switch status {
+	case StatusFinished, StatusAfterExtraTime, StatusAfterPenalties,
+		StatusCancelled, StatusAwarded, StatusWalkover:
+		return true
+	}
+	return false
+}
+
+// IsPlayed reports whether the status is a completed match whose scoreline counts.
+func IsPlayed(status string) bool {
+	return Outcome(status) == OutcomePlayed
+}
+
+// FixtureOutcome describes how a fixture counts in standings, TeamPath, team
+// statistics and schedules.
+type FixtureOutcome string
+
+// Fixture outcomes. A rescheduled fixture keeps its FixtureID and returns as
+// scheduled with a new Date; aggregations use its latest version only.
+const (
+	// OutcomeScheduled fixtures (TBD, NS) are listed in schedules and have no result.
+	OutcomeScheduled FixtureOutcome = "scheduled"
+	// OutcomeLive fixtures are listed in schedules; their score is not final.
+	OutcomeLive FixtureOutcome = "live"
+	// OutcomePlayed fixtures (FT, AET, PEN) count everywhere.
+	OutcomePlayed FixtureOutcome = "played"
+	// OutcomeAwarded fixtures (AWD, WO) count with their official scoreline in
+	// standings and TeamPath, but not in team statistics or head-to-head
+	// meetings since no match was completed.
+	OutcomeAwarded FixtureOutcome = "awarded"
+	// OutcomePostponed fixtures (PST) have no result and stay in schedules
+	// at their last known date until rescheduled.
+	OutcomePostponed FixtureOutcome = "postponed"
+	// OutcomeAbandoned fixtures (ABD) have no result and stay in schedules
+	// until replayed or awarded.
+	OutcomeAbandoned FixtureOutcome = "abandoned"
+	// OutcomeCancelled fixtures (CANC) have no result and leave schedules.
+	OutcomeCancelled FixtureOutcome = "cancelled"
+)
+
+// awardedGoals is the scoreline given to the winner of an awarded fixture
+// reported without goals.
+const awardedGoals = 3
+
+// Outcome returns the outcome of the given status. Unknown statuses are
+// treated as scheduled.
+func Outcome(status string) FixtureOutcome {
+	switch status {
+	case StatusFinished, StatusAfterExtraTime, StatusAfterPenalties:
+		return OutcomePlayed
+	case StatusAwarded, StatusWalkover:
+		return OutcomeAwarded
+	case StatusPostponed:
+		return OutcomePostponed
+	case StatusAbandoned:
+		return OutcomeAbandoned
+	case StatusCancelled:
+		return OutcomeCancelled
+	}
+	if IsLive(status) {
+		return OutcomeLive
+	}
+	return OutcomeScheduled
+}
+
+// CountsInStandings reports whether fixtures with the outcome have a result
+// in standings and TeamPath.
+func (o FixtureOutcome) CountsInStandings() bool {
+	return o == OutcomePlayed || o == OutcomeAwarded
+}
+
+// CountsInStatistics reports whether fixtures with the outcome count in team
+// statistics and head-to-head meetings.
+func (o FixtureOutcome) CountsInStatistics() bool {
+	return o == OutcomePlayed
+}
+
+// InSchedule reports whether fixtures with the outcome are listed as still to be played.
+func (o FixtureOutcome) InSchedule() bool {
+	switch o {
+	case OutcomeScheduled, OutcomeLive, OutcomePostponed, OutcomeAbandoned:
+		return true
+	}
+	return false
+}
+
+// Outcome returns the fixture's outcome.
+func (f FixtureData) Outcome() FixtureOutcome {
+	return Outcome(f.GameStatus)
+}
+
+// Result returns the final goals of a played or awarded fixture, excluding
+// penalty shoot-outs. An awarded fixture reported without goals is given 3-0
+// to the side named by Winner. It reports false for fixtures without a result.
+func (f FixtureData) Result() (home, away int, ok bool) {
+	outcome := f.Outcome()
+	if !outcome.CountsInStandings() {
+		return 0, 0, false
+	}
+	if outcome == OutcomeAwarded && f.GoalsHome == 0 && f.GoalsAway == 0 {
+		switch f.winnerSide() {
+		case "home":
+			return awardedGoals, 0, true
+		case "away":
+			return 0, awardedGoals, true
+		}
+	}
+	return f.GoalsHome, f.GoalsAway, true
+}
+
+// winnerSide returns "home" or "away" for the side Winner names, by side,
+// team name or team id, and "" otherwise.
+func (f FixtureData) winnerSide() string {
+	switch w := strings.TrimSpace(f.Winner); {
+	case w == "":
+		return ""
+	case strings.EqualFold(w, "home") || w == f.HomeTeam || w == strconv.Itoa(f.HomeTeamID):
+		return "home"
+	case strings.EqualFold(w, "away") || w == f.AwayTeam || w == strconv.Itoa(f.AwayTeamID):
+		return "away"
+	}
+	return ""
+}
@@ -0,0 +1,484 @@
+package client
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Update types carried by a FixtureUpdate.
+const (
+	UpdateTypeFixture = "fixture"
+	UpdateTypeEvent   = "event"
+)
+
+const (
+	defaultStreamHistory   = 1024
+	defaultStreamBuffer    = 64
+	defaultStreamHeartbeat = 15 * time.Second
+	websocketGUID          = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+// FixtureUpdate represents a single live change pushed to stream subscribers,
+// either a new snapshot of a fixture or a single event within it.
+type FixtureUpdate struct {
+	ID        uint64       `json:"id"`
+	Type      string       `json:"type"`
+	LeagueID  string       `json:"league_id"`
+	FixtureID string       `json:"fixture_id"`
+	Fixture   *FixtureData `json:"fixture,omitempty"`
+	Event     *Event       `json:"event,omitempty"`
+}
+
+// StreamFilter selects the updates a subscriber receives. An empty filter
+// receives every update, otherwise an update matches when either its league
+// or its fixture is listed.
+type StreamFilter struct {
+	LeagueIDs  []string `json:"league_ids"`
+	FixtureIDs []string `json:"fixture_ids"`
+}
+
+func (f StreamFilter) matches(u FixtureUpdate) bool {
+	if len(f.LeagueIDs) == 0 && len(f.FixtureIDs) == 0 {
+		return true
+	}
+	for _, id := range f.LeagueIDs {
+		if id == u.LeagueID {
+			return true
+		}
+	}
+	for _, id := range f.FixtureIDs {
+		if id == u.FixtureID {
+			return true
+		}
+	}
+	return false
+}
+
+// FixtureStream fans fixture updates out to Server-Sent Events and WebSocket
+// clients. It keeps a bounded history so reconnecting clients can resume from
+// the last event id they saw.
+type FixtureStream struct {
+	// Heartbeat is the interval between keep-alive messages sent to idle clients.
+	Heartbeat time.Duration
+	// History is the number of past updates retained for resuming clients.
+	History int
+	// Buffer is the number of pending updates a client may lag behind before
+	// it is disconnected and expected to resume.
+	Buffer int
+
+	mu          sync.Mutex
+	lastID      uint64
+	history     []FixtureUpdate
+	subscribers map[*streamSubscriber]struct{}
+}
+
+type streamSubscriber struct {
+	filter  StreamFilter
+	updates chan FixtureUpdate
+}
+
+// NewFixtureStream returns a FixtureStream with default history, buffer and heartbeat settings.
+func NewFixtureStream() *FixtureStream {
+	return &FixtureStream{
+		Heartbeat: defaultStreamHeartbeat,
+		History:   defaultStreamHistory,
+		Buffer:    defaultStreamBuffer,
+	}
+}
+
+// PublishFixture pushes a new snapshot of a fixture to subscribers.
+func (s *FixtureStream) PublishFixture(leagueID, fixtureID string, data FixtureData) FixtureUpdate {
+	return s.Publish(FixtureUpdate{Type: UpdateTypeFixture, LeagueID: leagueID, FixtureID: fixtureID, Fixture: &data})
+}
+
+// PublishEvent pushes a single fixture event to subscribers.
+func (s *FixtureStream) PublishEvent(leagueID, fixtureID string, event Event) FixtureUpdate {
+	return s.Publish(FixtureUpdate{Type: UpdateTypeEvent, LeagueID: leagueID, FixtureID: fixtureID, Event: &event})
+}
+
+// Publish assigns the next event id to the update, records it in the history and
+// delivers it to every matching subscriber. Subscribers that have fallen too far
+// behind are disconnected.
+func (s *FixtureStream) Publish(update FixtureUpdate) FixtureUpdate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	update.ID = s.lastID
+
+	s.history = append(s.history, update)
+	if limit := s.historyLimit(); len(s.history) > limit {
+		s.history = append(s.history[:0:0], s.history[len(s.history)-limit:]...)
+	}
+
+	for sub := range s.subscribers {
+		if !sub.filter.matches(update) {
+			continue
+		}
+		select {
+		case sub.updates <- update:
+		default:
+			delete(s.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+	return update
+}
+
+// Subscribe registers a subscriber for updates matching filter. Updates from the
+// history with an id greater than lastEventID are delivered first. The returned
+// channel is closed when the subscriber is cancelled or falls too far behind.
+func (s *FixtureStream) Subscribe(filter StreamFilter, lastEventID uint64) (<-chan FixtureUpdate, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var backlog []FixtureUpdate
+	if lastEventID > 0 {
+		for _, u := range s.history {
+			if u.ID > lastEventID && filter.matches(u) {
+				backlog = append(backlog, u)
+			}
+		}
+	}
+
+	buffer := s.Buffer
+	if buffer <= 0 {
+		buffer = defaultStreamBuffer
+	}
+	sub := &streamSubscriber{filter: filter, updates: make(chan FixtureUpdate, buffer+len(backlog))}
+	for _, u := range backlog {
+		sub.updates <- u
+	}
+
+	if s.subscribers == nil {
+		s.subscribers = make(map[*streamSubscriber]struct{})
+	}
+	s.subscribers[sub] = struct{}{}
+
+	cancel := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subscribers[sub]; ok {
+			delete(s.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+	return sub.updates, cancel
+}
+
+func (s *FixtureStream) historyLimit() int {
+	if s.History <= 0 {
+		return defaultStreamHistory
+	}
+	return s.History
+}
+
+func (s *FixtureStream) heartbeat() time.Duration {
+	if s.Heartbeat <= 0 {
+		return defaultStreamHeartbeat
+	}
+	return s.Heartbeat
+}
+
+// ServeHTTP streams updates to the client over WebSocket when the request asks
+// for an upgrade and over Server-Sent Events otherwise. Subscriptions are read
+// from the repeatable or comma separated "league" and "fixture" query parameters,
+// and the resume point from the Last-Event-ID header or "last_event_id" parameter.
+func (s *FixtureStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter := StreamFilter{
+		LeagueIDs:  queryList(r, "league"),
+		FixtureIDs: queryList(r, "fixture"),
+	}
+
+	lastEventID, err := lastEventID(r)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	if isWebSocketUpgrade(r) {
+		s.serveWebSocket(w, r, filter, lastEventID)
+		return
+	}
+	s.serveSSE(w, r, filter, lastEventID)
+}
+
+func (s *FixtureStream) serveSSE(w http.ResponseWriter, r *http.Request, filter StreamFilter, lastEventID uint64) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	updates, cancel := s.Subscribe(filter, lastEventID)
+	defer cancel()
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(s.heartbeat())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case u, ok := <-updates:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(u)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", u.ID, u.Type, payload); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *FixtureStream) serveWebSocket(w http.ResponseWriter, r *http.Request, filter StreamFilter, lastEventID uint64) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		WriteError(w, InvalidRequest(FieldError{Field: "Sec-WebSocket-Key", Message: "missing key or unsupported websocket version"}))
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket unsupported", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+	ws := &wsConn{rw: rw}
+	if err := ws.writeRaw("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"); err != nil {
+		return
+	}
+
+	updates, cancel := s.Subscribe(filter, lastEventID)
+	defer cancel()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		ws.readLoop()
+	}()
+
+	ticker := time.NewTicker(s.heartbeat())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+			if err := ws.writeFrame(wsOpPing, nil); err != nil {
+				return
+			}
+		case u, ok := <-updates:
+			if !ok {
+				_ = ws.writeFrame(wsOpClose, closePayload(1008, "subscriber too slow"))
+				return
+			}
+			payload, err := json.Marshal(u)
+			if err != nil {
+				return
+			}
+			if err := ws.writeFrame(wsOpText, payload); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func queryList(r *http.Request, name string) []string {
+	var out []string
+	for _, v := range r.URL.Query()[name] {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+func lastEventID(r *http.Request) (uint64, error) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, InvalidRequest(FieldError{Field: "last_event_id", Message: fmt.Sprintf("%q is not a valid event id", raw)})
+	}
+	return id, nil
+}
+
+func isWebSocketUpgrade(r *http.Request) bool {
+	return headerContains(r.Header, "Connection", "upgrade") &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+func headerContains(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, part := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WebSocket opcodes used by the stream (RFC 6455 section 5.2).
+const (
+	wsOpText  = 0x1
+	wsOpClose = 0x8
+	wsOpPing  = 0x9
+	wsOpPong  = 0xA
+)
+
+// wsConn is the minimal server side of a WebSocket connection: it writes
+// unmasked frames and reads client frames only to answer pings and closes.
+type wsConn struct {
+	mu sync.Mutex
+	rw *bufio.ReadWriter
+}
+
+func (c *wsConn) writeRaw(s string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.rw.WriteString(s); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// readLoop consumes client frames until the connection fails or the client closes it.
+func (c *wsConn) readLoop() {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case wsOpClose:
+			_ = c.writeFrame(wsOpClose, payload)
+			return
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode := head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > 1<<20 {
+		return 0, nil, fmt.Errorf("websocket frame too large: %d bytes", length)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return opcode, payload, nil
+}
+
+func closePayload(code uint16, reason string) []byte {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, code)
+	return append(payload, reason...)
+}
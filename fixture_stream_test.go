@@ -0,0 +1,252 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseEvent reads the next Server-Sent Event or comment block from br and
+// returns its lines.
+func sseEvent(t *testing.T, br *bufio.Reader) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read event: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			if len(lines) > 0 {
+				return lines
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+}
+
+func openSSE(t *testing.T, url string, header http.Header) (*http.Response, *bufio.Reader) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp, bufio.NewReader(resp.Body)
+}
+
+func TestFixtureStreamSSEResumeAndFilter(t *testing.T) {
+	s := NewFixtureStream()
+	s.PublishFixture("39", "1", FixtureData{HomeTeam: "Arsenal"})
+	s.PublishFixture("40", "2", FixtureData{HomeTeam: "Leeds"})
+	s.PublishEvent("39", "1", Event{Player: "Saka"})
+	srv := httptest.NewServer(s)
+	// Close waits for open streams, so it must be registered before the
+	// body cleanup in openSSE to run after it.
+	t.Cleanup(srv.Close)
+
+	resp, br := openSSE(t, srv.URL+"?league=39", http.Header{"Last-Event-Id": {"1"}})
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	want := []string{"id: 3", "event: event"}
+	if got := sseEvent(t, br); got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("resumed event = %q, want prefix %q", got, want)
+	}
+
+	s.PublishFixture("40", "2", FixtureData{HomeTeam: "Leeds"})
+	s.PublishFixture("39", "1", FixtureData{HomeTeam: "Arsenal", GoalsHome: 1})
+	got := sseEvent(t, br)
+	if got[0] != "id: 5" || got[1] != "event: fixture" {
+		t.Fatalf("live event = %q, want id 5 fixture", got)
+	}
+	var u FixtureUpdate
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(got[2], "data: ")), &u); err != nil {
+		t.Fatal(err)
+	}
+	if u.LeagueID != "39" || u.Fixture == nil || u.Fixture.GoalsHome != 1 {
+		t.Fatalf("payload = %+v", u)
+	}
+}
+
+func TestFixtureStreamSSEResumeFromQuery(t *testing.T) {
+	s := NewFixtureStream()
+	s.PublishFixture("39", "1", FixtureData{})
+	s.PublishFixture("39", "2", FixtureData{})
+	s.PublishFixture("39", "3", FixtureData{})
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	_, br := openSSE(t, srv.URL+"?fixture=1,3&last_event_id=1", nil)
+	if got := sseEvent(t, br); got[0] != "id: 3" {
+		t.Fatalf("event = %q, want id 3", got)
+	}
+}
+
+func TestFixtureStreamSSEHeartbeat(t *testing.T) {
+	s := NewFixtureStream()
+	s.Heartbeat = 10 * time.Millisecond
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	_, br := openSSE(t, srv.URL, nil)
+	if got := sseEvent(t, br); len(got) != 1 || got[0] != ": heartbeat" {
+		t.Fatalf("heartbeat = %q", got)
+	}
+}
+
+func TestFixtureStreamInvalidLastEventID(t *testing.T) {
+	srv := httptest.NewServer(NewFixtureStream())
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "?last_event_id=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+}
+
+// dialWebSocket performs the opening handshake against srv and returns the
+// connection with the response status line and headers consumed.
+func dialWebSocket(t *testing.T, srv *httptest.Server, path string) (net.Conn, *wsConn, []string) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// Key and accept value from the example in RFC 6455 section 1.3.
+	_, err = conn.Write([]byte("GET " + path + " HTTP/1.1\r\n" +
+		"Host: example.com\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: keep-alive, Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(conn)
+	var head []string
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read handshake: %v", err)
+		}
+		if line == "\r\n" {
+			break
+		}
+		head = append(head, strings.TrimRight(line, "\r\n"))
+	}
+	return conn, &wsConn{rw: bufio.NewReadWriter(br, bufio.NewWriter(conn))}, head
+}
+
+func TestFixtureStreamWebSocketUpgrade(t *testing.T) {
+	srv := httptest.NewServer(NewFixtureStream())
+	t.Cleanup(srv.Close)
+
+	_, _, head := dialWebSocket(t, srv, "/")
+	if head[0] != "HTTP/1.1 101 Switching Protocols" {
+		t.Fatalf("status line = %q", head[0])
+	}
+	found := false
+	for _, h := range head[1:] {
+		if h == "Sec-WebSocket-Accept: s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("missing accept header in %q", head)
+	}
+}
+
+func TestFixtureStreamWebSocketFrames(t *testing.T) {
+	s := NewFixtureStream()
+	s.PublishFixture("39", "1", FixtureData{HomeTeam: "Arsenal"})
+	s.PublishFixture("39", "2", FixtureData{HomeTeam: "Chelsea"})
+	s.PublishEvent("39", "1", Event{Player: "Saka"})
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	_, ws, _ := dialWebSocket(t, srv, "/?fixture=1&last_event_id=1")
+
+	op, payload, err := ws.readFrame()
+	if err != nil || op != wsOpText {
+		t.Fatalf("frame = %#x, %v", op, err)
+	}
+	var u FixtureUpdate
+	if err := json.Unmarshal(payload, &u); err != nil {
+		t.Fatal(err)
+	}
+	if u.ID != 3 || u.Type != UpdateTypeEvent || u.Event == nil || u.Event.Player != "Saka" {
+		t.Fatalf("update = %+v", u)
+	}
+
+	// A client ping is answered with a pong carrying the same payload.
+	if err := ws.writeFrame(wsOpPing, []byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+	op, payload, err = ws.readFrame()
+	if err != nil || op != wsOpPong || string(payload) != "hi" {
+		t.Fatalf("pong = %#x %q, %v", op, payload, err)
+	}
+
+	// A client close is echoed before the server hangs up.
+	if err := ws.writeFrame(wsOpClose, closePayload(1000, "bye")); err != nil {
+		t.Fatal(err)
+	}
+	op, _, err = ws.readFrame()
+	if err != nil || op != wsOpClose {
+		t.Fatalf("close = %#x, %v", op, err)
+	}
+}
+
+func TestFixtureStreamWebSocketHeartbeat(t *testing.T) {
+	s := NewFixtureStream()
+	s.Heartbeat = 10 * time.Millisecond
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	_, ws, _ := dialWebSocket(t, srv, "/")
+	if op, _, err := ws.readFrame(); err != nil || op != wsOpPing {
+		t.Fatalf("frame = %#x, %v, want ping", op, err)
+	}
+}
+
+func TestFixtureStreamWebSocketBadVersion(t *testing.T) {
+	srv := httptest.NewServer(NewFixtureStream())
+	t.Cleanup(srv.Close)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Sec-WebSocket-Version", "8")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+}
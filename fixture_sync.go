@@ -0,0 +1,268 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSyncRecentWindow = 3 * 24 * time.Hour
+	defaultSyncLookAhead    = 14 * 24 * time.Hour
+	defaultSyncFullInterval = 7 * 24 * time.Hour
+)
+
+// FixtureQuery selects the fixtures of a league season fetched from the upstream API.
+// Zero From and To select the whole season.
+type FixtureQuery struct {
+	LeagueID string    `json:"league_id"`
+	Season   string    `json:"season"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+}
+
+// FixtureSource fetches fixtures from the upstream API.
+type FixtureSource interface {
+	Fixtures(ctx context.Context, query FixtureQuery) ([]GeneralFixtureData, error)
+}
+
+// SyncWatermark records how far a league season has been synchronised.
+// UpdatedAt is the latest fixture UpdateAt stored; LastRunAt and LastFullAt
+// are the clock times of the last successful run and full run.
+type SyncWatermark struct {
+	LeagueID   string    `json:"league_id" bson:"league_id"`
+	Season     string    `json:"season" bson:"season"`
+	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+	LastRunAt  time.Time `json:"last_run_at" bson:"last_run_at"`
+	LastFullAt time.Time `json:"last_full_at" bson:"last_full_at"`
+}
+
+// SyncRun summarises a single synchronisation of a league season.
+type SyncRun struct {
+	ID         string    `json:"id" bson:"id"`
+	LeagueID   string    `json:"league_id" bson:"league_id"`
+	Season     string    `json:"season" bson:"season"`
+	Full       bool      `json:"full" bson:"full"`
+	From       time.Time `json:"from" bson:"from"`
+	To         time.Time `json:"to" bson:"to"`
+	StartedAt  time.Time `json:"started_at" bson:"started_at"`
+	FinishedAt time.Time `json:"finished_at" bson:"finished_at"`
+	Fetched    int       `json:"fetched" bson:"fetched"`
+	Inserted   int       `json:"inserted" bson:"inserted"`
+	Updated    int       `json:"updated" bson:"updated"`
+	Unchanged  int       `json:"unchanged" bson:"unchanged"`
+	Failed     int       `json:"failed" bson:"failed"`
+	Error      string    `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// SyncStore persists watermarks and the history of sync runs.
+type SyncStore interface {
+	Watermark(ctx context.Context, leagueID, season string) (SyncWatermark, bool, error)
+	SaveWatermark(ctx context.Context, watermark SyncWatermark) error
+	RecordRun(ctx context.Context, run SyncRun) error
+}
+
+// FixtureSyncer incrementally synchronises league seasons into a FixtureRepository.
+// The first run of a season, and any run FullInterval after the last full one,
+// fetches every fixture. Other runs fetch the fixtures kicking off in a window
+// from RecentWindow before the previous run to LookAhead after now. Fixtures
+// are written only when their data changed, judged by UpdateAt where known.
+// Changes to fixtures outside the window, such as a late correction to an old
+// result or a reschedule beyond LookAhead, are picked up by the next full run.
+type FixtureSyncer struct {
+	Source     FixtureSource
+	Repository FixtureRepository
+	Store      SyncStore
+	Clock      Clock
+	// RecentWindow is how far before the previous run kickoffs are re-fetched,
+	// to catch late corrections of recently finished fixtures.
+	RecentWindow time.Duration
+	// LookAhead is how far past now kickoffs are fetched, to catch reschedules.
+	LookAhead time.Duration
+	// FullInterval is how often the whole season is fetched again. Zero or
+	// less only fetches it on the first run.
+	FullInterval time.Duration
+}
+
+// NewFixtureSyncer returns a FixtureSyncer with default windows.
+func NewFixtureSyncer(source FixtureSource, repo FixtureRepository, store SyncStore) *FixtureSyncer {
+	return &FixtureSyncer{
+		Source:       source,
+		Repository:   repo,
+		Store:        store,
+		Clock:        SystemClock{},
+		RecentWindow: defaultSyncRecentWindow,
+		LookAhead:    defaultSyncLookAhead,
+		FullInterval: defaultSyncFullInterval,
+	}
+}
+
+// Sync synchronises a league season and records the run. Failures to store
+// individual fixtures are counted and joined into the returned error without
+// stopping the run; the watermark only advances when every fixture was stored.
+func (s *FixtureSyncer) Sync(ctx context.Context, leagueID, season string) (SyncRun, error) {
+	clock := s.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	now := clock.Now()
+
+	run := SyncRun{ID: newID(), LeagueID: leagueID, Season: season, StartedAt: now}
+
+	wm, found, err := s.Store.Watermark(ctx, leagueID, season)
+	if err != nil {
+		return s.finish(ctx, clock, run, fmt.Errorf("load watermark: %w", err))
+	}
+
+	query := FixtureQuery{LeagueID: leagueID, Season: season}
+	run.Full = !found || (s.FullInterval > 0 && !now.Before(wm.LastFullAt.Add(s.FullInterval)))
+	if !run.Full {
+		// The kickoff window covers every fixture that kicked off since the
+		// previous run; the watermark's UpdatedAt is an update time and plays
+		// no part in it.
+		from := wm.LastRunAt
+		if from.IsZero() || from.After(now) {
+			from = now
+		}
+		query.From = from.Add(-s.RecentWindow)
+		query.To = now.Add(s.LookAhead)
+	}
+	run.From, run.To = query.From, query.To
+
+	fixtures, err := s.Source.Fixtures(ctx, query)
+	if err != nil {
+		return s.finish(ctx, clock, run, fmt.Errorf("fetch fixtures: %w", err))
+	}
+	run.Fetched = len(fixtures)
+
+	watermark := wm.UpdatedAt
+	var errs []error
+	for _, f := range fixtures {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if f.LeagueID == "" {
+			f.LeagueID = leagueID
+		}
+		if err := s.apply(ctx, f, &run); err != nil {
+			run.Failed++
+			errs = append(errs, fmt.Errorf("fixture %s: %w", f.FixtureID, err))
+			continue
+		}
+		if f.FixtureData.UpdateAt.After(watermark) {
+			watermark = f.FixtureData.UpdateAt
+		}
+	}
+
+	if len(errs) == 0 {
+		if watermark.IsZero() {
+			watermark = now
+		}
+		next := SyncWatermark{LeagueID: leagueID, Season: season, UpdatedAt: watermark, LastRunAt: now, LastFullAt: wm.LastFullAt}
+		if run.Full {
+			next.LastFullAt = now
+		}
+		if err := s.Store.SaveWatermark(ctx, next); err != nil {
+			errs = append(errs, fmt.Errorf("save watermark: %w", err))
+		}
+	}
+	return s.finish(ctx, clock, run, errors.Join(errs...))
+}
+
+// apply stores a fetched fixture if it is new or changed and counts the outcome on run.
+func (s *FixtureSyncer) apply(ctx context.Context, fetched GeneralFixtureData, run *SyncRun) error {
+	existing, found, err := s.Repository.GetFixture(ctx, fetched.FixtureID)
+	if err != nil {
+		return err
+	}
+	if found && !fixtureChanged(existing, fetched) {
+		run.Unchanged++
+		return nil
+	}
+	if err := s.Repository.UpsertFixture(ctx, fetched); err != nil {
+		return err
+	}
+	if found {
+		run.Updated++
+	} else {
+		run.Inserted++
+	}
+	return nil
+}
+
+func (s *FixtureSyncer) finish(ctx context.Context, clock Clock, run SyncRun, err error) (SyncRun, error) {
+	run.FinishedAt = clock.Now()
+	if err != nil {
+		run.Error = err.Error()
+	}
+	if recErr := s.Store.RecordRun(ctx, run); recErr != nil {
+		err = errors.Join(err, fmt.Errorf("record run: %w", recErr))
+	}
+	return run, err
+}
+
+// fixtureChanged reports whether fetched differs from the stored fixture. A
+// fetched fixture whose UpdateAt is not newer than the stored one is unchanged.
+func fixtureChanged(stored, fetched GeneralFixtureData) bool {
+	su, fu := stored.FixtureData.UpdateAt, fetched.FixtureData.UpdateAt
+	if !su.IsZero() && !fu.IsZero() && !fu.After(su) {
+		return false
+	}
+	return !reflect.DeepEqual(stored, fetched)
+}
+
+// MemorySyncStore is an in-memory SyncStore.
+type MemorySyncStore struct {
+	mu         sync.Mutex
+	watermarks map[string]SyncWatermark
+	runs       []SyncRun
+}
+
+// Watermark returns the watermark of a league season and whether one exists.
+func (s *MemorySyncStore) Watermark(_ context.Context, leagueID, season string) (SyncWatermark, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wm, ok := s.watermarks[leagueID+"/"+season]
+	return wm, ok, nil
+}
+
+// SaveWatermark stores the watermark of a league season.
+func (s *MemorySyncStore) SaveWatermark(_ context.Context, watermark SyncWatermark) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watermarks == nil {
+		s.watermarks = make(map[string]SyncWatermark)
+	}
+	s.watermarks[watermark.LeagueID+"/"+watermark.Season] = watermark
+	return nil
+}
+
+// RecordRun appends a run to the history.
+func (s *MemorySyncStore) RecordRun(_ context.Context, run SyncRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs = append(s.runs, run)
+	return nil
+}
+
+// Runs returns the recorded runs in the order they finished.
+func (s *MemorySyncStore) Runs() []SyncRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SyncRun(nil), s.runs...)
+}
+
+// newID returns a random 128-bit identifier in hex.
+func newID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("generate id: %v", err))
+	}
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// windowSource serves fixtures filtered by kickoff date like the upstream API.
+type windowSource struct {
+	fixtures []GeneralFixtureData
+	queries  []FixtureQuery
+}
+
+func (s *windowSource) Fixtures(_ context.Context, q FixtureQuery) ([]GeneralFixtureData, error) {
+	s.queries = append(s.queries, q)
+	var out []GeneralFixtureData
+	for _, f := range s.fixtures {
+		d := f.FixtureData.Date
+		if (!q.From.IsZero() && d.Before(q.From)) || (!q.To.IsZero() && d.After(q.To)) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+func TestFixtureSyncerWindows(t *testing.T) {
+	start := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	src := &windowSource{fixtures: []GeneralFixtureData{
+		{FixtureID: "old", FixtureData: FixtureData{Date: start.AddDate(0, 0, -20), GoalsHome: 1, UpdateAt: start.AddDate(0, 0, -20)}},
+		{FixtureID: "recent", FixtureData: FixtureData{Date: start.AddDate(0, 0, -1), UpdateAt: start.AddDate(0, 0, -1)}},
+		{FixtureID: "next", FixtureData: FixtureData{Date: start.AddDate(0, 0, 5), UpdateAt: start.AddDate(0, 0, -2)}},
+	}}
+	repo := NewMemoryFixtureRepository()
+	store := &MemorySyncStore{}
+	s := NewFixtureSyncer(src, repo, store)
+	s.Clock = clock
+	ctx := context.Background()
+
+	run, err := s.Sync(ctx, "39", "2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !run.Full || run.Inserted != 3 {
+		t.Fatalf("first run = %+v, want a full run inserting 3", run)
+	}
+
+	// Two days later the old result is corrected: its kickoff is outside the
+	// incremental window although it was updated after the watermark.
+	clock.Advance(48 * time.Hour)
+	src.fixtures[0].FixtureData.GoalsHome = 2
+	src.fixtures[0].FixtureData.UpdateAt = clock.Now()
+	run, err = s.Sync(ctx, "39", "2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := src.queries[len(src.queries)-1]
+	if wantFrom, wantTo := start.Add(-s.RecentWindow), clock.Now().Add(s.LookAhead); !q.From.Equal(wantFrom) || !q.To.Equal(wantTo) {
+		t.Fatalf("incremental window = %v..%v, want %v..%v", q.From, q.To, wantFrom, wantTo)
+	}
+	if run.Full || run.Fetched != 2 || run.Unchanged != 2 {
+		t.Fatalf("incremental run = %+v", run)
+	}
+
+	// The next full run picks the correction up.
+	clock.Advance(s.FullInterval)
+	run, err = s.Sync(ctx, "39", "2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !run.Full || run.Updated != 1 || run.Unchanged != 2 {
+		t.Fatalf("full run = %+v", run)
+	}
+	stored, _, _ := repo.GetFixture(ctx, "old")
+	if stored.FixtureData.GoalsHome != 2 {
+		t.Fatalf("old fixture goals = %d, want corrected 2", stored.FixtureData.GoalsHome)
+	}
+
+	wm, _, _ := store.Watermark(ctx, "39", "2024")
+	if !wm.LastFullAt.Equal(clock.Now()) || !wm.UpdatedAt.Equal(start.Add(48*time.Hour)) {
+		t.Fatalf("watermark = %+v", wm)
+	}
+	if n := len(store.Runs()); n != 3 {
+		t.Fatalf("recorded %d runs, want 3", n)
+	}
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"sort"
+	"time"
+)
+
+// HeadToHead summarises the past meetings of two teams, seen from the home
+// and away sides of the fixture it was built for.
+type HeadToHead struct {
+	HomeTeamID int                 `json:"home_team_id" bson:"home_team_id"`
+	HomeTeam   string              `json:"home_team" bson:"home_team"`
+	AwayTeamID int                 `json:"away_team_id" bson:"away_team_id"`
+	AwayTeam   string              `json:"away_team" bson:"away_team"`
+	All        HeadToHeadRecord    `json:"all" bson:"all"`
+	AtHome     HeadToHeadRecord    `json:"at_home" bson:"at_home"`
+	AtAway     HeadToHeadRecord    `json:"at_away" bson:"at_away"`
+	Meetings   []HeadToHeadMeeting `json:"meetings" bson:"meetings"`
+}
+
+// HeadToHeadRecord aggregates results over a set of meetings. AtHome counts
+// the meetings hosted by the home team, AtAway those hosted by the away team.
+type HeadToHeadRecord struct {
+	Played        int `json:"played" bson:"played"`
+	HomeTeamWins  int `json:"home_team_wins" bson:"home_team_wins"`
+	Draws         int `json:"draws" bson:"draws"`
+	AwayTeamWins  int `json:"away_team_wins" bson:"away_team_wins"`
+	HomeTeamGoals int `json:"home_team_goals" bson:"home_team_goals"`
+	AwayTeamGoals int `json:"away_team_goals" bson:"away_team_goals"`
+}
+
+// HeadToHeadMeeting is a single past fixture between the two teams.
+type HeadToHeadMeeting struct {
+	FixtureID  string    `json:"fixture_id" bson:"fixture_id"`
+	Date       time.Time `json:"date" bson:"date"`
+	LeagueName string    `json:"league_name" bson:"league_name"`
+	HomeTeamID int       `json:"home_team_id" bson:"home_team_id"`
+	HomeTeam   string    `json:"home_team" bson:"home_team"`
+	AwayTeamID int       `json:"away_team_id" bson:"away_team_id"`
+	AwayTeam   string    `json:"away_team" bson:"away_team"`
+	GoalsHome  int       `json:"goals_home" bson:"goals_home"`
+	GoalsAway  int       `json:"goals_away" bson:"goals_away"`
+	GameStatus string    `json:"game_status" bson:"game_status"`
+}
+
+// BuildHeadToHead collects the last played meetings of homeTeamID and
+// awayTeamID in fixtures that kicked off before the given time (any time when
+// zero), newest first, and aggregates them. A non-positive last keeps every
+// meeting. Awarded fixtures are not meetings as no match was completed.
+func BuildHeadToHead(fixtures []GeneralFixtureData, homeTeamID, awayTeamID int, before time.Time, last int) HeadToHead {
+	h2h := HeadToHead{HomeTeamID: homeTeamID, AwayTeamID: awayTeamID}
+	fixtures = LatestFixtures(fixtures)
+
+	var meetings []int
+	for i, g := range fixtures {
+		f := g.FixtureData
+		sameTeams := (f.HomeTeamID == homeTeamID && f.AwayTeamID == awayTeamID) ||
+			(f.HomeTeamID == awayTeamID && f.AwayTeamID == homeTeamID)
+		if !sameTeams || !IsPlayed(f.GameStatus) {
+			continue
+		}
+		if !before.IsZero() && !f.Date.Before(before) {
+			continue
+		}
+		meetings = append(meetings, i)
+	}
+	sort.SliceStable(meetings, func(i, j int) bool {
+		return fixtures[meetings[i]].FixtureData.Date.After(fixtures[meetings[j]].FixtureData.Date)
+	})
+	if last > 0 && len(meetings) > last {
+		meetings = meetings[:last]
+	}
+
+	for _, i := range meetings {
+		f := fixtures[i].FixtureData
+		h2h.Meetings = append(h2h.Meetings, HeadToHeadMeeting{
+			FixtureID:  fixtures[i].FixtureID,
+			Date:       f.Date,
+			LeagueName: f.LeagueName,
+			HomeTeamID: f.HomeTeamID,
+			HomeTeam:   f.HomeTeam,
+			AwayTeamID: f.AwayTeamID,
+			AwayTeam:   f.AwayTeam,
+			GoalsHome:  f.GoalsHome,
+			GoalsAway:  f.GoalsAway,
+			GameStatus: f.GameStatus,
+		})
+
+		var homeGoals, awayGoals int
+		if f.HomeTeamID == homeTeamID {
+			homeGoals, awayGoals = f.GoalsHome, f.GoalsAway
+			h2h.HomeTeam, h2h.AwayTeam = f.HomeTeam, f.AwayTeam
+			h2h.AtHome.add(homeGoals, awayGoals)
+		} else {
+			homeGoals, awayGoals = f.GoalsAway, f.GoalsHome
+			h2h.HomeTeam, h2h.AwayTeam = f.AwayTeam, f.HomeTeam
+			h2h.AtAway.add(homeGoals, awayGoals)
+		}
+		h2h.All.add(homeGoals, awayGoals)
+	}
+	return h2h
+}
+
+// HeadToHeadFor builds the head-to-head of a fixture from the meetings in
+// history that kicked off before it.
+func HeadToHeadFor(fixture GeneralFixtureData, history []GeneralFixtureData, last int) HeadToHead {
+	f := fixture.FixtureData
+	h2h := BuildHeadToHead(history, f.HomeTeamID, f.AwayTeamID, f.Date, last)
+	h2h.HomeTeam, h2h.AwayTeam = f.HomeTeam, f.AwayTeam
+	return h2h
+}
+
+// AttachHeadToHead embeds the fixture's head-to-head built from history.
+func (g *GeneralFixtureData) AttachHeadToHead(history []GeneralFixtureData, last int) {
+	h2h := HeadToHeadFor(*g, history, last)
+	g.HeadToHead = &h2h
+}
+
+func (r *HeadToHeadRecord) add(homeGoals, awayGoals int) {
+	r.Played++
+	r.HomeTeamGoals += homeGoals
+	r.AwayTeamGoals += awayGoals
+	switch {
+	case homeGoals > awayGoals:
+		r.HomeTeamWins++
+	case homeGoals < awayGoals:
+		r.AwayTeamWins++
+	default:
+		r.Draws++
+	}
+}
@@ -0,0 +1,256 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// JobKindLeagueAdd is the kind of job created when a league is added.
+const JobKindLeagueAdd = "league_add"
+
+// ErrJobActive is returned when a job is created while another job of the
+// same kind and subject is not done yet.
+var ErrJobActive = errors.New("a job for this subject is already active")
+
+// JobRegistry stores jobs so their status can be queried while and after they
+// run. CreateJob fails with ErrJobActive while another job of the same kind
+// and subject is not done, so that two jobs never share a subject's state.
+type JobRegistry interface {
+	CreateJob(ctx context.Context, job Job) error
+	UpdateJob(ctx context.Context, job Job) error
+	GetJob(ctx context.Context, id string) (Job, bool, error)
+	ListJobs(ctx context.Context, kind string) ([]Job, error)
+}
+
+// MemoryJobRegistry is an in-memory JobRegistry.
+type MemoryJobRegistry struct {
+	mu   sync.RWMutex
+	jobs map[string]Job
+}
+
+// CreateJob stores a new job, failing if its id is already taken or another
+// job of the same kind and subject is active.
+func (r *MemoryJobRegistry) CreateJob(_ context.Context, job Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.jobs == nil {
+		r.jobs = make(map[string]Job)
+	}
+	if _, ok := r.jobs[job.ID]; ok {
+		return fmt.Errorf("job %s already exists", job.ID)
+	}
+	for _, other := range r.jobs {
+		if other.Kind == job.Kind && other.Subject == job.Subject && !other.State.Done() {
+			return fmt.Errorf("%w: %s %s is job %s", ErrJobActive, job.Kind, job.Subject, other.ID)
+		}
+	}
+	r.jobs[job.ID] = cloneJob(job)
+	return nil
+}
+
+// UpdateJob replaces a stored job.
+func (r *MemoryJobRegistry) UpdateJob(_ context.Context, job Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.jobs[job.ID]; !ok {
+		return fmt.Errorf("job %s not found", job.ID)
+	}
+	r.jobs[job.ID] = cloneJob(job)
+	return nil
+}
+
+// GetJob returns the job with the given id and whether it exists.
+func (r *MemoryJobRegistry) GetJob(_ context.Context, id string) (Job, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, ok := r.jobs[id]
+	return cloneJob(job), ok, nil
+}
+
+// ListJobs returns the jobs of the given kind, or all jobs for an empty kind, newest first.
+func (r *MemoryJobRegistry) ListJobs(_ context.Context, kind string) ([]Job, error) {
+	r.mu.RLock()
+	out := make([]Job, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		if kind == "" || job.Kind == kind {
+			out = append(out, cloneJob(job))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].ID < out[j].ID
+		}
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func cloneJob(job Job) Job {
+	job.Steps = append([]JobStep(nil), job.Steps...)
+	return job
+}
+
+// JobStepFunc performs a single named step of a job.
+type JobStepFunc func(ctx context.Context, step string) error
+
+// StartJob registers a pending job with one step per name and runs the steps
+// sequentially in the background, recording each step's outcome in the
+// registry. The background work is detached from ctx cancellation. It returns
+// the job as registered.
+func StartJob(ctx context.Context, registry JobRegistry, clock Clock, kind, subject string, steps []string, fn JobStepFunc) (Job, error) {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	job := Job{
+		ID:        newID(),
+		Kind:      kind,
+		Subject:   subject,
+		State:     JobPending,
+		Progress:  JobProgress{Total: len(steps)},
+		CreatedAt: clock.Now(),
+	}
+	for _, name := range steps {
+		job.Steps = append(job.Steps, JobStep{Name: name, State: JobPending})
+	}
+	if err := registry.CreateJob(ctx, job); err != nil {
+		return Job{}, err
+	}
+
+	go runJob(context.WithoutCancel(ctx), registry, clock, cloneJob(job), fn)
+	return job, nil
+}
+
+func runJob(ctx context.Context, registry JobRegistry, clock Clock, job Job, fn JobStepFunc) {
+	// Registry errors cannot be reported to anyone here; the job keeps
+	// running so its work is not lost and later updates may still succeed.
+	update := func() { _ = registry.UpdateJob(ctx, cloneJob(job)) }
+
+	job.State = JobRunning
+	job.StartedAt = clock.Now()
+	update()
+
+	var failed int
+	for i := range job.Steps {
+		step := &job.Steps[i]
+		step.State = JobRunning
+		step.StartedAt = clock.Now()
+		update()
+
+		err := runJobStep(ctx, fn, step.Name)
+
+		step.FinishedAt = clock.Now()
+		if err != nil {
+			failed++
+			step.State = JobFailed
+			step.Error = err.Error()
+		} else {
+			step.State = JobSucceeded
+		}
+		job.Progress.Done++
+		update()
+	}
+
+	switch {
+	case failed == 0:
+		job.State = JobSucceeded
+	case failed == len(job.Steps):
+		job.State = JobFailed
+	default:
+		job.State = JobPartial
+	}
+	job.FinishedAt = clock.Now()
+	update()
+}
+
+// runJobStep runs fn, turning a panic into a step error so one bad step does not kill the process.
+func runJobStep(ctx context.Context, fn JobStepFunc, step string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("step %s panicked: %v", step, r)
+		}
+	}()
+	return fn(ctx, step)
+}
+
+// Start adds a league asynchronously: it registers a job with one step per
+// season, backfills the seasons in the background and returns immediately
+// with the job in the response. It fails with ErrJobActive while another add
+// of the same league is running, as both would share its checkpoint.
+func (b *Backfill) Start(ctx context.Context, registry JobRegistry, req BackfillRequest) (LeagueAddResponse, error) {
+	if err := req.Validate(); err != nil {
+		body := AsError(err).Body()
+		return LeagueAddResponse{Message: err.Error(), Error: &body}, err
+	}
+	job, err := StartJob(ctx, registry, b.Clock, JobKindLeagueAdd, req.LeagueID, req.Seasons,
+		func(ctx context.Context, season string) error {
+			res, err := b.Run(ctx, BackfillRequest{LeagueID: req.LeagueID, Seasons: []string{season}})
+			if err != nil {
+				return err
+			}
+			if res.StoppedReason != "" {
+				return errors.New("backfill stopped: " + res.StoppedReason)
+			}
+			if !res.Completed {
+				return fmt.Errorf("%d fixtures failed", res.Seasons[0].Failed)
+			}
+			return nil
+		})
+	if err != nil {
+		return LeagueAddResponse{}, err
+	}
+	return LeagueAddResponse{
+		Message: fmt.Sprintf("league %s add started for %d seasons", req.LeagueID, len(req.Seasons)),
+		JobID:   job.ID,
+		Job:     &job,
+	}, nil
+}
+
+// JobStatusHandler serves the status of jobs in registry as JSON. A request
+// with an "id" path wildcard (e.g. mounted at "GET /jobs/{id}") or query
+// parameter returns that job, any other request lists the jobs, filtered by
+// the optional "kind" query parameter.
+func JobStatusHandler(registry JobRegistry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := r.PathValue("id")
+		if id == "" {
+			id = r.URL.Query().Get("id")
+		}
+
+		var body any
+		if id == "" {
+			jobs, err := registry.ListJobs(r.Context(), r.URL.Query().Get("kind"))
+			if err != nil {
+				WriteError(w, err)
+				return
+			}
+			body = jobs
+		} else {
+			job, ok, err := registry.GetJob(r.Context(), id)
+			if err != nil {
+				WriteError(w, err)
+				return
+			}
+			if !ok {
+				WriteError(w, NotFound("job", id))
+				return
+			}
+			body = job
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+}
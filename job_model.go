@@ -0,0 +1,48 @@
+package client
+
+import "time"
+
+// JobState is the lifecycle state of an ingestion job or one of its steps.
+type JobState string
+
+// Job states. A job is partial when some of its steps failed and others succeeded.
+const (
+	JobPending   JobState = "pending"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobPartial   JobState = "partial"
+	JobFailed    JobState = "failed"
+)
+
+// Done reports whether the state is terminal.
+func (s JobState) Done() bool {
+	return s == JobSucceeded || s == JobPartial || s == JobFailed
+}
+
+// Job represents an asynchronous ingestion job, such as adding a league, and its progress.
+type Job struct {
+	ID         string      `json:"id" bson:"id"`
+	Kind       string      `json:"kind" bson:"kind"`
+	Subject    string      `json:"subject" bson:"subject"`
+	State      JobState    `json:"state" bson:"state"`
+	Progress   JobProgress `json:"progress" bson:"progress"`
+	Steps      []JobStep   `json:"steps" bson:"steps"`
+	CreatedAt  time.Time   `json:"created_at" bson:"created_at"`
+	StartedAt  time.Time   `json:"started_at" bson:"started_at"`
+	FinishedAt time.Time   `json:"finished_at" bson:"finished_at"`
+}
+
+// JobProgress counts the finished steps of a job.
+type JobProgress struct {
+	Done  int `json:"done" bson:"done"`
+	Total int `json:"total" bson:"total"`
+}
+
+// JobStep is a single unit of work within a job, with its own state and error.
+type JobStep struct {
+	Name       string    `json:"name" bson:"name"`
+	State      JobState  `json:"state" bson:"state"`
+	Error      string    `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at" bson:"started_at"`
+	FinishedAt time.Time `json:"finished_at" bson:"finished_at"`
+}
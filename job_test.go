@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// gatedSource serves three fixtures per season, each fetch waiting on gate.
+type gatedSource struct {
+	gate chan struct{}
+}
+
+func (s *gatedSource) FixtureIDs(_ context.Context, _, season string) ([]string, error) {
+	return []string{season + "-1", season + "-2", season + "-3"}, nil
+}
+
+func (s *gatedSource) Fixture(ctx context.Context, id string) (GeneralFixtureData, error) {
+	select {
+	case <-s.gate:
+	case <-ctx.Done():
+		return GeneralFixtureData{}, ctx.Err()
+	}
+	return GeneralFixtureData{FixtureID: id}, nil
+}
+
+func waitForJob(t *testing.T, registry JobRegistry, id string) Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, ok, err := registry.GetJob(context.Background(), id)
+		if err != nil || !ok {
+			t.Fatalf("GetJob(%s) = %v, %v", id, ok, err)
+		}
+		if job.State.Done() {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s still %s", id, job.State)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBackfillStartRejectsConcurrentJobs(t *testing.T) {
+	src := &gatedSource{gate: make(chan struct{})}
+	b := &Backfill{Source: src, Repository: NewMemoryFixtureRepository(), Checkpoints: FileCheckpointStore{Dir: t.TempDir()}}
+	registry := &MemoryJobRegistry{}
+	req, err := NewBackfillRequest(LeagueRequest{LeagueID: "39", Season: "2023"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	first, err := b.Start(ctx, registry, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Start(ctx, registry, req); !errors.Is(err, ErrJobActive) {
+		t.Fatalf("second start = %v, want ErrJobActive", err)
+	}
+	other, err := NewBackfillRequest(LeagueRequest{LeagueID: "140", Season: "2023"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := b.Start(ctx, registry, other)
+	if err != nil {
+		t.Fatalf("start for another league = %v", err)
+	}
+
+	close(src.gate)
+	job := waitForJob(t, registry, first.JobID)
+	if job.State != JobSucceeded || job.Progress != (JobProgress{Done: 2, Total: 2}) {
+		t.Fatalf("job = %+v", job)
+	}
+	waitForJob(t, registry, second.JobID)
+
+	if _, err := b.Start(ctx, registry, req); err != nil {
+		t.Fatalf("start after the first job finished = %v", err)
+	}
+}
+
+func TestStartJobStates(t *testing.T) {
+	registry := &MemoryJobRegistry{}
+	job, err := StartJob(context.Background(), registry, nil, "test", "subject", []string{"ok", "fail", "panic"},
+		func(_ context.Context, step string) error {
+			switch step {
+			case "fail":
+				return errors.New("boom")
+			case "panic":
+				panic("bad step")
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job = waitForJob(t, registry, job.ID)
+	if job.State != JobPartial {
+		t.Fatalf("state = %s, want partial", job.State)
+	}
+	want := []JobState{JobSucceeded, JobFailed, JobFailed}
+	for i, step := range job.Steps {
+		if step.State != want[i] {
+			t.Errorf("step %s = %s, want %s", step.Name, step.State, want[i])
+		}
+	}
+	if job.Steps[2].Error != "step panic panicked: bad step" {
+		t.Errorf("panic error = %q", job.Steps[2].Error)
+	}
+}
+
+func TestJobStatusHandler(t *testing.T) {
+	registry := &MemoryJobRegistry{}
+	ctx := context.Background()
+	for _, job := range []Job{
+		{ID: "a", Kind: JobKindLeagueAdd, Subject: "39", State: JobSucceeded, CreatedAt: time.Unix(1, 0)},
+		{ID: "b", Kind: "other", Subject: "39", State: JobRunning, CreatedAt: time.Unix(2, 0)},
+	} {
+		if err := registry.CreateJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /jobs/{id}", JobStatusHandler(registry))
+	mux.Handle("GET /jobs", JobStatusHandler(registry))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	var job Job
+	if status := getJSON(t, srv.URL+"/jobs/b", &job); status != http.StatusOK || job.ID != "b" {
+		t.Fatalf("GET /jobs/b = %d %+v", status, job)
+	}
+	var jobs []Job
+	if status := getJSON(t, srv.URL+"/jobs?kind="+JobKindLeagueAdd, &jobs); status != http.StatusOK || len(jobs) != 1 || jobs[0].ID != "a" {
+		t.Fatalf("GET /jobs?kind = %d %+v", status, jobs)
+	}
+	var envelope ErrorEnvelope
+	if status := getJSON(t, srv.URL+"/jobs/missing", &envelope); status != http.StatusNotFound || envelope.Error.Code != CodeNotFound {
+		t.Fatalf("GET /jobs/missing = %d %+v", status, envelope)
+	}
+}
+
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
@@ -1,14 +1,21 @@
 package client
 
+import "time"
+
 // LeagueRequest represents the request to retrieve a league's data.
 type LeagueRequest struct {
-	LeagueID string `json:"league_id""`
-	Season   string `json:"season""`
+	LeagueID string `json:"league_id"`
+	Season   string `json:"season"`
 }
 
 // LeagueAddResponse represents the response to adding a league's data.
+// Job is set when the league is added asynchronously and tracks its ingestion,
+// Error is set when the league could not be added.
 type LeagueAddResponse struct {
-	Message string `json:"message"`
+	Message string     `json:"message"`
+	JobID   string     `json:"job_id,omitempty"`
+	Job     *Job       `json:"job,omitempty"`
+	Error   *ErrorBody `json:"error,omitempty"`
 }
 
 // League represents a football league including its basic details and the teams and standings within it.
@@ -34,45 +41,52 @@ type TeamPath struct {
 
 // RoundFixture provides the details of a single fixture in a league round for a specific team.
 type RoundFixture struct {
-	Round            string `json:"round" bson:"round"`
-	RoundNum         int    `json:"round_number" bson:"round_number"`
-	FixtureID        string `json:"fixture_id" bson:"fixture_id"`
-	HomeGame         bool   `json:"home_game" bson:"home_game"`
-	AgainstTeam      string `json:"against_team" bson:"against_team"`
-	AgainstTeamID    string `json:"against_team_id" bson:"against_team_id"`
-	ResultForTeam    string `json:"result_for_team" bson:"result_for_team"`
-	Points           int    `json:"points" bson:"points"`
-	Goals            int    `json:"goals" bson:"goals"`
-	GoalsAgainst     int    `json:"goals_against" bson:"goals_against"`
-	TotalGoal        int    `json:"total_goal" bson:"total_goal"`
-	TotalGoalAgainst int    `json:"total_goal_against" bson:"total_goal_against"`
+	Round            string    `json:"round" bson:"round"`
+	RoundNum         int       `json:"round_number" bson:"round_number"`
+	FixtureID        string    `json:"fixture_id" bson:"fixture_id"`
+	Date             time.Time `json:"date" bson:"date"`
+	HomeGame         bool      `json:"home_game" bson:"home_game"`
+	AgainstTeam      string    `json:"against_team" bson:"against_team"`
+	AgainstTeamID    string    `json:"against_team_id" bson:"against_team_id"`
+	ResultForTeam    string    `json:"result_for_team" bson:"result_for_team"`
+	Points           int       `json:"points" bson:"points"`
+	Goals            int       `json:"goals" bson:"goals"`
+	GoalsAgainst     int       `json:"goals_against" bson:"goals_against"`
+	TotalGoal        int       `json:"total_goal" bson:"total_goal"`
+	TotalGoalAgainst int       `json:"total_goal_against" bson:"total_goal_against"`
 }
 
 // Standings details the current standings of a team within its league.
+// Points include PointsAdjustment, the sum of the Adjustments applied to the
+// team. AwardedFixtures lists the fixtures whose result was awarded or
+// overridden rather than played.
 type Standings struct {
-	Rank             int    `json:"rank" bson:"rank"`
-	Team             string `json:"team" bson:"team"`
-	TeamID           int    `json:"team_id" bson:"team_id"`
-	TeamLogo         string `json:"team_logo" bson:"team_logo"`
-	Points           int    `json:"points" bson:"points"`
-	GoalsDiff        int    `json:"goal_diff" bson:"goals_diff"`
-	Form             string `json:"form" bson:"form"`
-	Played           int    `json:"played" bson:"played"`
-	Wins             int    `json:"wins" bson:"wins"`
-	Draws            int    `json:"draws" bson:"draws"`
-	Losses           int    `json:"losses" bson:"losses"`
-	GoalsFor         int    `json:"goals_for" bson:"goals_for"`
-	GoalsAgainst     int    `json:"goals_against" bson:"goals_against"`
-	HomePlayed       int    `json:"home_played" bson:"home_played"`
-	HomeWins         int    `json:"home_wins" bson:"home_wins"`
-	HomeDraws        int    `json:"home_draws" bson:"home_draws"`
-	HomeLosses       int    `json:"home_losses" bson:"home_losses"`
-	HomeGoalsFor     int    `json:"home_goals_for" bson:"home_goals_for"`
-	HomeGoalsAgainst int    `json:"home_goals_against" bson:"home_goals_against"`
-	AwayPlayed       int    `json:"away_played" bson:"away_played"`
-	AwayWins         int    `json:"away_wins" bson:"away_wins"`
-	AwayDraws        int    `json:"away_draws" bson:"away_draws"`
-	AwayLosses       int    `json:"away_losses" bson:"away_losses"`
-	AwayGoalsFor     int    `json:"away_goals_for" bson:"away_goals_for"`
-	AwayGoalsAgainst int    `json:"away_goals_against" bson:"away_goals_against"`
+	Rank             int          `json:"rank" bson:"rank"`
+	Team             string       `json:"team" bson:"team"`
+	TeamID           int          `json:"team_id" bson:"team_id"`
+	TeamLogo         string       `json:"team_logo" bson:"team_logo"`
+	Points           int          `json:"points" bson:"points"`
+	GoalsDiff        int          `json:"goal_diff" bson:"goals_diff"`
+	Form             string       `json:"form" bson:"form"`
+	Played           int          `json:"played" bson:"played"`
+	Wins             int          `json:"wins" bson:"wins"`
+	Draws            int          `json:"draws" bson:"draws"`
+	Losses           int          `json:"losses" bson:"losses"`
+	GoalsFor         int          `json:"goals_for" bson:"goals_for"`
+	GoalsAgainst     int          `json:"goals_against" bson:"goals_against"`
+	HomePlayed       int          `json:"home_played" bson:"home_played"`
+	HomeWins         int          `json:"home_wins" bson:"home_wins"`
+	HomeDraws        int          `json:"home_draws" bson:"home_draws"`
+	HomeLosses       int          `json:"home_losses" bson:"home_losses"`
+	HomeGoalsFor     int          `json:"home_goals_for" bson:"home_goals_for"`
+	HomeGoalsAgainst int          `json:"home_goals_against" bson:"home_goals_against"`
+	AwayPlayed       int          `json:"away_played" bson:"away_played"`
+	AwayWins         int          `json:"away_wins" bson:"away_wins"`
+	AwayDraws        int          `json:"away_draws" bson:"away_draws"`
+	AwayLosses       int          `json:"away_losses" bson:"away_losses"`
+	AwayGoalsFor     int          `json:"away_goals_for" bson:"away_goals_for"`
+	AwayGoalsAgainst int          `json:"away_goals_against" bson:"away_goals_against"`
+	PointsAdjustment int          `json:"points_adjustment,omitempty" bson:"points_adjustment,omitempty"`
+	Adjustments      []Adjustment `json:"adjustments,omitempty" bson:"adjustments,omitempty"`
+	AwardedFixtures  []string     `json:"awarded_fixtures,omitempty" bson:"awarded_fixtures,omitempty"`
 }
@@ -0,0 +1,306 @@
+package client
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxGoals is the highest score per side the score grid covers.
+const defaultMaxGoals = 10
+
+// defaultPriorMatches is the weight, in matches, of the league average in
+// team strengths.
+const defaultPriorMatches = 5
+
+// Default over/under lines reported in MatchProbabilities.
+var defaultGoalLines = []float64{0.5, 1.5, 2.5, 3.5, 4.5}
+
+// LeagueAverages holds the mean goals per match scored by home and away sides
+// in a league, used to normalise team strengths.
+type LeagueAverages struct {
+	HomeGoals float64 `json:"home_goals" bson:"home_goals"`
+	AwayGoals float64 `json:"away_goals" bson:"away_goals"`
+}
+
+// LeagueAveragesFromFixtures computes league averages from the played fixtures.
+// It returns the DefaultGoalRateModel rates when none has been played.
+func LeagueAveragesFromFixtures(fixtures []GeneralFixtureData) LeagueAverages {
+	var home, away, n int
+	for _, g := range LatestFixtures(fixtures) {
+		f := g.FixtureData
+		if !f.Outcome().CountsInStatistics() {
+			continue
+		}
+		home += f.GoalsHome
+		away += f.GoalsAway
+		n++
+	}
+	if n == 0 {
+		return defaultLeagueAverages()
+	}
+	return LeagueAverages{HomeGoals: float64(home) / float64(n), AwayGoals: float64(away) / float64(n)}
+}
+
+// LeagueAveragesFromStatistics computes league averages from the teams' home
+// and away scoring, weighted by matches played. Malformed teams are skipped.
+func LeagueAveragesFromStatistics(stats []TeamStatistics) LeagueAverages {
+	var home, away float64
+	var homePlayed, awayPlayed int
+	for _, s := range stats {
+		h, errHome := parseGoalAverage(s.GoalAvgHome)
+		a, errAway := parseGoalAverage(s.GoalAvgAway)
+		if errHome != nil || errAway != nil {
+			continue
+		}
+		home += h * float64(s.PlayedHome)
+		away += a * float64(s.PlayedAway)
+		homePlayed += s.PlayedHome
+		awayPlayed += s.PlayedAway
+	}
+	if homePlayed == 0 || awayPlayed == 0 {
+		return defaultLeagueAverages()
+	}
+	return LeagueAverages{HomeGoals: home / float64(homePlayed), AwayGoals: away / float64(awayPlayed)}
+}
+
+func defaultLeagueAverages() LeagueAverages {
+	m := DefaultGoalRateModel()
+	return LeagueAverages{HomeGoals: m.HomeGoals, AwayGoals: m.AwayGoals}
+}
+
+// TeamStrength is a team's attack and defence relative to the league average,
+// 1 being average. Defence above 1 concedes more than average.
+type TeamStrength struct {
+	HomeAttack  float64 `json:"home_attack" bson:"home_attack"`
+	HomeDefence float64 `json:"home_defence" bson:"home_defence"`
+	AwayAttack  float64 `json:"away_attack" bson:"away_attack"`
+	AwayDefence float64 `json:"away_defence" bson:"away_defence"`
+}
+
+// MatchProbabilities describes the predicted outcome of a fixture.
+// Scores[h][a] is the probability of the score h-a.
+type MatchProbabilities struct {
+	ExpectedHomeGoals float64     `json:"expected_home_goals" bson:"expected_home_goals"`
+	ExpectedAwayGoals float64     `json:"expected_away_goals" bson:"expected_away_goals"`
+	HomeWin           float64     `json:"home_win" bson:"home_win"`
+	Draw              float64     `json:"draw" bson:"draw"`
+	AwayWin           float64     `json:"away_win" bson:"away_win"`
+	BothTeamsScore    float64     `json:"both_teams_score" bson:"both_teams_score"`
+	OverUnder         []OverUnder `json:"over_under" bson:"over_under"`
+	Scores            [][]float64 `json:"scores" bson:"scores"`
+}
+
+// OverUnder gives the probabilities of total goals above and below a line.
+type OverUnder struct {
+	Line  float64 `json:"line" bson:"line"`
+	Over  float64 `json:"over" bson:"over"`
+	Under float64 `json:"under" bson:"under"`
+}
+
+// Score returns the probability of the exact score, 0 beyond the grid.
+func (p MatchProbabilities) Score(home, away int) float64 {
+	if home < 0 || away < 0 || home >= len(p.Scores) || away >= len(p.Scores[home]) {
+		return 0
+	}
+	return p.Scores[home][away]
+}
+
+// Over returns the probability of more total goals than line.
+func (p MatchProbabilities) Over(line float64) float64 {
+	var over float64
+	for h, row := range p.Scores {
+		for a, prob := range row {
+			if float64(h+a) > line {
+				over += prob
+			}
+		}
+	}
+	return over
+}
+
+// PoissonModel predicts fixtures with independent Poisson goal counts whose
+// means combine the teams' strengths from TeamStatistics with league averages.
+type PoissonModel struct {
+	League LeagueAverages
+	// PriorMatches is the number of league-average matches blended into each
+	// team's goal averages, shrinking strengths from few matches towards 1.
+	PriorMatches float64
+	// MaxGoals is the highest score per side in the score grid.
+	MaxGoals int
+	// Lines are the over/under lines reported.
+	Lines []float64
+}
+
+// NewPoissonModel returns a PoissonModel normalised by league. Zero averages
+// fall back to the DefaultGoalRateModel rates.
+func NewPoissonModel(league LeagueAverages) *PoissonModel {
+	if league.HomeGoals <= 0 || league.AwayGoals <= 0 {
+		league = defaultLeagueAverages()
+	}
+	return &PoissonModel{League: league, PriorMatches: defaultPriorMatches, MaxGoals: defaultMaxGoals, Lines: defaultGoalLines}
+}
+
+// Strength derives a team's strengths from its goal averages, weighted by the
+// matches played against PriorMatches at league average. Averages that are
+// missing or come from no match at home or away count as league average;
+// malformed ones are an error.
+func (m *PoissonModel) Strength(s TeamStatistics) (TeamStrength, error) {
+	prior := max(m.PriorMatches, 0)
+	values := [4]float64{}
+	for i, field := range [4]struct {
+		name, value string
+		played      int
+		league      float64
+	}{
+		{"goal_avg_home", s.GoalAvgHome, s.PlayedHome, m.League.HomeGoals},
+		{"against_goal_avg_home", s.AgainstGoalAvgHome, s.PlayedHome, m.League.AwayGoals},
+		{"goal_avg_away", s.GoalAvgAway, s.PlayedAway, m.League.AwayGoals},
+		{"against_goal_avg_away", s.AgainstGoalAvgAway, s.PlayedAway, m.League.HomeGoals},
+	} {
+		avg, err := parseGoalAverage(field.value)
+		if err != nil {
+			return TeamStrength{}, DataInconsistent("team %q has a malformed %s %q", s.TeamName, field.name, field.value)
+		}
+		values[i] = 1
+		if strings.TrimSpace(field.value) != "" && field.played > 0 && field.league > 0 {
+			n := float64(field.played)
+			values[i] = (n*avg/field.league + prior) / (n + prior)
+		}
+	}
+	return TeamStrength{HomeAttack: values[0], HomeDefence: values[1], AwayAttack: values[2], AwayDefence: values[3]}, nil
+}
+
+// ExpectedGoals returns the mean goals of each side given their statistics.
+func (m *PoissonModel) ExpectedGoals(home, away TeamStatistics) (float64, float64, error) {
+	h, err := m.Strength(home)
+	if err != nil {
+		return 0, 0, err
+	}
+	a, err := m.Strength(away)
+	if err != nil {
+		return 0, 0, err
+	}
+	return h.HomeAttack * a.AwayDefence * m.League.HomeGoals, a.AwayAttack * h.HomeDefence * m.League.AwayGoals, nil
+}
+
+// Predict returns the outcome probabilities of a fixture from its
+// HomeTeamStats and AwayTeamStats.
+func (m *PoissonModel) Predict(g GeneralFixtureData) (MatchProbabilities, error) {
+	home, away, err := m.ExpectedGoals(g.HomeTeamStats, g.AwayTeamStats)
+	if err != nil {
+		return MatchProbabilities{}, err
+	}
+	return m.probabilities(home, away), nil
+}
+
+// OutcomeModel returns an OutcomeModel drawing scores from the model, with
+// team statistics looked up by team name. Teams without statistics are
+// taken as league average.
+func (m *PoissonModel) OutcomeModel(stats map[string]TeamStatistics) OutcomeModel {
+	return OutcomeModelFunc(func(f FixtureData, rng *rand.Rand) (int, int) {
+		home, away, err := m.ExpectedGoals(stats[f.HomeTeam], stats[f.AwayTeam])
+		if err != nil {
+			home, away = m.League.HomeGoals, m.League.AwayGoals
+		}
+		return poissonSample(rng, home), poissonSample(rng, away)
+	})
+}
+
+// probabilities builds the score grid for the given means and derives the
+// markets from it.
+func (m *PoissonModel) probabilities(home, away float64) MatchProbabilities {
+	return scoreProbabilities(home, away, m.MaxGoals, m.Lines, nil)
+}
+
+// scoreProbabilities builds the score grid of independent Poisson goal counts
+// with the given means, each score weighted by adjust when set, normalises it
+// and derives the markets from it.
+func scoreProbabilities(home, away float64, maxGoals int, lines []float64, adjust func(h, a int) float64) MatchProbabilities {
+	if maxGoals <= 0 {
+		maxGoals = defaultMaxGoals
+	}
+	if lines == nil {
+		lines = defaultGoalLines
+	}
+
+	p := MatchProbabilities{ExpectedHomeGoals: home, ExpectedAwayGoals: away}
+	p.Scores = make([][]float64, maxGoals+1)
+	var total float64
+	for h := range p.Scores {
+		p.Scores[h] = make([]float64, maxGoals+1)
+		for a := range p.Scores[h] {
+			prob := poissonPMF(h, home) * poissonPMF(a, away)
+			if adjust != nil {
+				prob *= adjust(h, a)
+			}
+			p.Scores[h][a] = prob
+			total += prob
+		}
+	}
+	for h, row := range p.Scores {
+		for a := range row {
+			row[a] /= total
+			switch {
+			case h > a:
+				p.HomeWin += row[a]
+			case h < a:
+				p.AwayWin += row[a]
+			default:
+				p.Draw += row[a]
+			}
+			if h > 0 && a > 0 {
+				p.BothTeamsScore += row[a]
+			}
+		}
+	}
+	for _, line := range lines {
+		over := p.Over(line)
+		p.OverUnder = append(p.OverUnder, OverUnder{Line: line, Over: over, Under: 1 - over})
+	}
+	return p
+}
+
+// Sample draws a score from the grid.
+func (p MatchProbabilities) Sample(rng *rand.Rand) (home, away int) {
+	u := rng.Float64()
+	for h, row := range p.Scores {
+		for a, prob := range row {
+			if u < prob {
+				return h, a
+			}
+			if prob > 0 {
+				home, away = h, a
+			}
+			u -= prob
+		}
+	}
+	// Rounding left u above the total: keep the last possible score.
+	return home, away
+}
+
+// poissonPMF returns the probability of k events for the given mean.
+func poissonPMF(k int, mean float64) float64 {
+	if mean <= 0 {
+		if k == 0 {
+			return 1
+		}
+		return 0
+	}
+	logP := float64(k)*math.Log(mean) - mean
+	for i := 2; i <= k; i++ {
+		logP -= math.Log(float64(i))
+	}
+	return math.Exp(logP)
+}
+
+// parseGoalAverage parses an API-Football goal average such as "1.5". An
+// empty value parses as 0.
+func parseGoalAverage(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPoissonModelStrength(t *testing.T) {
+	m := NewPoissonModel(LeagueAverages{HomeGoals: 1.5, AwayGoals: 1.2})
+	tests := []struct {
+		name  string
+		prior float64
+		stats TeamStatistics
+		want  TeamStrength
+	}{
+		{"no matches", 5, TeamStatistics{GoalAvgHome: "0.0", AgainstGoalAvgHome: "0.0", GoalAvgAway: "0.0", AgainstGoalAvgAway: "0.0"},
+			TeamStrength{1, 1, 1, 1}},
+		{"missing averages", 5, TeamStatistics{PlayedHome: 3, PlayedAway: 3}, TeamStrength{1, 1, 1, 1}},
+		{"raw ratios", 0, TeamStatistics{PlayedHome: 5, GoalAvgHome: "2.0", AgainstGoalAvgHome: "0.6", PlayedAway: 5, GoalAvgAway: "0.6", AgainstGoalAvgAway: "3.0"},
+			TeamStrength{2 / 1.5, 0.5, 0.5, 2}},
+		// 5 matches at twice the average blended with 5 at the average.
+		{"shrunk", 5, TeamStatistics{PlayedHome: 5, GoalAvgHome: "3.0", AgainstGoalAvgHome: "0.6", PlayedAway: 15, GoalAvgAway: "0.6", AgainstGoalAvgAway: "3.0"},
+			TeamStrength{1.5, 0.75, 0.625, 1.75}},
+		{"home only", 5, TeamStatistics{PlayedHome: 5, GoalAvgHome: "3.0", AgainstGoalAvgHome: "1.2", GoalAvgAway: "0.0", AgainstGoalAvgAway: "0.0"},
+			TeamStrength{1.5, 1, 1, 1}},
+	}
+	for _, tt := range tests {
+		m.PriorMatches = tt.prior
+		got, err := m.Strength(tt.stats)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !approx(got.HomeAttack, tt.want.HomeAttack) || !approx(got.HomeDefence, tt.want.HomeDefence) ||
+			!approx(got.AwayAttack, tt.want.AwayAttack) || !approx(got.AwayDefence, tt.want.AwayDefence) {
+			t.Errorf("%s: Strength = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := m.Strength(TeamStatistics{PlayedHome: 1, GoalAvgHome: "two"}); err == nil {
+		t.Errorf("malformed average accepted")
+	}
+}
+
+func TestPoissonModelExpectedGoals(t *testing.T) {
+	m := NewPoissonModel(LeagueAverages{HomeGoals: 1.5, AwayGoals: 1.2})
+	home := TeamStatistics{PlayedHome: 5, GoalAvgHome: "3.0", AgainstGoalAvgHome: "0.6"}
+	away := TeamStatistics{PlayedAway: 15, GoalAvgAway: "0.6", AgainstGoalAvgAway: "3.0"}
+	h, a, err := m.ExpectedGoals(home, away)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1.5 attack x 1.75 defence x 1.5 and 0.625 attack x 0.75 defence x 1.2.
+	if !approx(h, 3.9375) || !approx(a, 0.5625) {
+		t.Fatalf("expected goals = %v, %v, want 3.9375, 0.5625", h, a)
+	}
+}
+
+func TestPoissonProbabilitiesSumToOne(t *testing.T) {
+	m := NewPoissonModel(LeagueAverages{})
+	m.MaxGoals = 20
+	for _, means := range [][2]float64{{1, 1}, {2.9, 0.4}, {0.2, 0.3}} {
+		p := m.probabilities(means[0], means[1])
+		var grid float64
+		for _, row := range p.Scores {
+			for _, prob := range row {
+				grid += prob
+			}
+		}
+		if !approx(grid, 1) || !approx(p.HomeWin+p.Draw+p.AwayWin, 1) {
+			t.Errorf("means %v: grid %v, outcomes %v", means, grid, p.HomeWin+p.Draw+p.AwayWin)
+		}
+		for _, ou := range p.OverUnder {
+			if !approx(ou.Over+ou.Under, 1) {
+				t.Errorf("means %v line %v: over+under = %v", means, ou.Line, ou.Over+ou.Under)
+			}
+		}
+	}
+
+	p := m.probabilities(1, 1)
+	if !approx(p.Score(0, 0), math.Exp(-2)) || !approx(p.Score(1, 0), math.Exp(-2)) || !approx(p.HomeWin, p.AwayWin) {
+		t.Errorf("means 1, 1: 0-0 %v, 1-0 %v, home %v, away %v", p.Score(0, 0), p.Score(1, 0), p.HomeWin, p.AwayWin)
+	}
+	if got, want := p.Over(0.5), 1-math.Exp(-2); !approx(got, want) {
+		t.Errorf("means 1, 1: over 0.5 = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,350 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FetchFixtureFunc retrieves the latest data for a single fixture from the upstream API.
+type FetchFixtureFunc func(ctx context.Context, fixtureID string) (FixtureData, error)
+
+// PollPolicy defines how often a fixture is polled depending on its status and kickoff time.
+type PollPolicy struct {
+	// InPlayInterval applies while the ball is in play (1H, 2H, ET, P).
+	InPlayInterval time.Duration
+	// BreakInterval applies during half time, breaks and interruptions.
+	BreakInterval time.Duration
+	// PreMatchWindow is how long before kickoff polling switches to PreMatchInterval.
+	PreMatchWindow time.Duration
+	// PreMatchInterval applies to not started fixtures inside the pre-match window.
+	PreMatchInterval time.Duration
+	// FarFutureInterval is the longest gap between polls of a future, postponed
+	// or abandoned fixture.
+	FarFutureInterval time.Duration
+	// OverdueInterval applies to fixtures past their kickoff that are not reported live yet.
+	OverdueInterval time.Duration
+	// ErrorInterval is the delay before retrying a fixture whose fetch failed.
+	ErrorInterval time.Duration
+	// DailyBudget caps the number of fetches per UTC day. Zero means unlimited.
+	DailyBudget int
+}
+
+// DefaultPollPolicy returns a PollPolicy suited to live score ingestion without a daily budget.
+func DefaultPollPolicy() PollPolicy {
+	return PollPolicy{
+		InPlayInterval:    15 * time.Second,
+		BreakInterval:     time.Minute,
+		PreMatchWindow:    2 * time.Hour,
+		PreMatchInterval:  10 * time.Minute,
+		FarFutureInterval: 12 * time.Hour,
+		OverdueInterval:   time.Minute,
+		ErrorInterval:     time.Minute,
+	}
+}
+
+// withDefaults replaces durations that are not positive, which would poll in a
+// hot loop, with those of DefaultPollPolicy.
+func (p PollPolicy) withDefaults() PollPolicy {
+	d := DefaultPollPolicy()
+	p.InPlayInterval = positiveOr(p.InPlayInterval, d.InPlayInterval)
+	p.BreakInterval = positiveOr(p.BreakInterval, d.BreakInterval)
+	p.PreMatchWindow = positiveOr(p.PreMatchWindow, d.PreMatchWindow)
+	p.PreMatchInterval = positiveOr(p.PreMatchInterval, d.PreMatchInterval)
+	p.FarFutureInterval = positiveOr(p.FarFutureInterval, d.FarFutureInterval)
+	p.OverdueInterval = positiveOr(p.OverdueInterval, d.OverdueInterval)
+	p.ErrorInterval = positiveOr(p.ErrorInterval, d.ErrorInterval)
+	return p
+}
+
+// Interval returns how long to wait before polling the fixture again, ignoring
+// the daily budget. It returns false when the fixture no longer needs polling.
+// Durations that are not positive fall back to those of DefaultPollPolicy.
+func (p PollPolicy) Interval(data FixtureData, now time.Time) (time.Duration, bool) {
+	p = p.withDefaults()
+	switch {
+	case data.Finished || IsFinal(data.GameStatus):
+		return 0, false
+	case IsInPlay(data.GameStatus):
+		return p.InPlayInterval, true
+	case IsBreak(data.GameStatus):
+		return p.BreakInterval, true
+	case data.GameStatus == StatusPostponed || data.GameStatus == StatusAbandoned:
+		return p.FarFutureInterval, true
+	}
+
+	untilKickoff := data.Date.Sub(now)
+	switch {
+	case untilKickoff <= 0:
+		return p.OverdueInterval, true
+	case untilKickoff <= p.PreMatchWindow:
+		return minDuration(p.PreMatchInterval, untilKickoff), true
+	default:
+		return minDuration(p.FarFutureInterval, untilKickoff-p.PreMatchWindow), true
+	}
+}
+
+// ScheduledPoll describes the next planned fetch of a tracked fixture.
+type ScheduledPoll struct {
+	FixtureID string    `json:"fixture_id"`
+	At        time.Time `json:"at"`
+}
+
+// PollScheduler polls tracked fixtures through a user supplied fetch function,
+// spacing requests according to a PollPolicy and stretching intervals when the
+// projected demand would exceed the daily budget.
+type PollScheduler struct {
+	Policy PollPolicy
+	Fetch  FetchFixtureFunc
+	Clock  Clock
+	// OnError, if set, receives fetch errors encountered by Run.
+	OnError func(err error)
+
+	mu      sync.Mutex
+	entries map[string]*pollEntry
+	day     time.Time
+	used    int
+	wake    chan struct{}
+}
+
+type pollEntry struct {
+	data FixtureData
+	next time.Time
+}
+
+// NewPollScheduler returns a PollScheduler using the given policy, fetch function and clock.
+// A nil clock defaults to SystemClock. Policy durations that are not positive
+// are replaced with those of DefaultPollPolicy.
+func NewPollScheduler(policy PollPolicy, fetch FetchFixtureFunc, clock Clock) *PollScheduler {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &PollScheduler{
+		Policy:  policy.withDefaults(),
+		Fetch:   fetch,
+		Clock:   clock,
+		entries: make(map[string]*pollEntry),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Track adds or replaces a fixture and schedules its next poll from its current data.
+// Fixtures that no longer need polling are not tracked.
+func (s *PollScheduler) Track(fixtureID string, data FixtureData) {
+	s.mu.Lock()
+	now := s.Clock.Now()
+	s.rollDayLocked(now)
+	if s.entries == nil {
+		s.entries = make(map[string]*pollEntry)
+	}
+	if _, ok := s.Policy.Interval(data, now); !ok {
+		delete(s.entries, fixtureID)
+	} else {
+		e := &pollEntry{data: data}
+		s.entries[fixtureID] = e
+		e.next = s.nextPollLocked(data, now)
+	}
+	s.mu.Unlock()
+	s.notify()
+}
+
+// Untrack stops polling a fixture.
+func (s *PollScheduler) Untrack(fixtureID string) {
+	s.mu.Lock()
+	delete(s.entries, fixtureID)
+	s.mu.Unlock()
+	s.notify()
+}
+
+// NextPoll returns the next planned poll time of a fixture.
+func (s *PollScheduler) NextPoll(fixtureID string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[fixtureID]
+	if !ok {
+		return time.Time{}, false
+	}
+	return e.next, true
+}
+
+// Schedule returns the planned polls of every tracked fixture ordered by time.
+func (s *PollScheduler) Schedule() []ScheduledPoll {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ScheduledPoll, 0, len(s.entries))
+	for id, e := range s.entries {
+		out = append(out, ScheduledPoll{FixtureID: id, At: e.next})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].At.Equal(out[j].At) {
+			return out[i].FixtureID < out[j].FixtureID
+		}
+		return out[i].At.Before(out[j].At)
+	})
+	return out
+}
+
+// Used returns the number of fetches made during the current UTC day.
+func (s *PollScheduler) Used() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rollDayLocked(s.Clock.Now())
+	return s.used
+}
+
+// RunDue fetches every fixture whose poll time has come, reschedules it from the
+// fetched data and returns the number of fetches made. Fetch errors are joined
+// into the returned error and the affected fixtures are retried after ErrorInterval.
+func (s *PollScheduler) RunDue(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	now := s.Clock.Now()
+	s.rollDayLocked(now)
+	var due []string
+	for id, e := range s.entries {
+		if !e.next.After(now) {
+			due = append(due, id)
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(due)
+
+	var (
+		polled int
+		errs   []error
+	)
+	for _, id := range due {
+		if err := ctx.Err(); err != nil {
+			return polled, err
+		}
+		if !s.reserve(id) {
+			continue
+		}
+		polled++
+
+		data, err := s.Fetch(ctx, id)
+
+		s.mu.Lock()
+		now := s.Clock.Now()
+		e, ok := s.entries[id]
+		switch {
+		case !ok:
+		case err != nil:
+			e.next = now.Add(s.Policy.withDefaults().ErrorInterval)
+			errs = append(errs, fmt.Errorf("fetch fixture %s: %w", id, err))
+		default:
+			if _, active := s.Policy.Interval(data, now); !active {
+				delete(s.entries, id)
+				break
+			}
+			e.data = data
+			e.next = s.nextPollLocked(data, now)
+		}
+		s.mu.Unlock()
+	}
+	return polled, errors.Join(errs...)
+}
+
+// Run polls fixtures as they become due until ctx is cancelled.
+func (s *PollScheduler) Run(ctx context.Context) error {
+	for {
+		if _, err := s.RunDue(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if s.OnError != nil {
+				s.OnError(err)
+			}
+		}
+
+		wait := s.Policy.withDefaults().FarFutureInterval
+		if schedule := s.Schedule(); len(schedule) > 0 {
+			wait = schedule[0].At.Sub(s.Clock.Now())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.wake:
+		case <-s.Clock.After(wait):
+		}
+	}
+}
+
+// reserve consumes one request from the daily budget for the fixture, or
+// postpones the fixture to the next UTC day when the budget is exhausted.
+func (s *PollScheduler) reserve(fixtureID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[fixtureID]
+	if !ok {
+		return false
+	}
+	now := s.Clock.Now()
+	s.rollDayLocked(now)
+	if s.Policy.DailyBudget > 0 && s.used >= s.Policy.DailyBudget {
+		e.next = s.day.Add(24 * time.Hour)
+		return false
+	}
+	s.used++
+	return true
+}
+
+// nextPollLocked returns the next poll time for data, stretching the policy
+// interval when the demand of all tracked fixtures until the end of the day
+// exceeds the remaining budget.
+func (s *PollScheduler) nextPollLocked(data FixtureData, now time.Time) time.Time {
+	interval, _ := s.Policy.Interval(data, now)
+	if s.Policy.DailyBudget <= 0 {
+		return now.Add(interval)
+	}
+
+	endOfDay := s.day.Add(24 * time.Hour)
+	remaining := s.Policy.DailyBudget - s.used
+	if remaining <= 0 {
+		return endOfDay
+	}
+
+	left := endOfDay.Sub(now)
+	var demand float64
+	for _, e := range s.entries {
+		if d, ok := s.Policy.Interval(e.data, now); ok && d > 0 {
+			demand += float64(left) / float64(d)
+		}
+	}
+	if factor := demand / float64(remaining); factor > 1 {
+		interval = time.Duration(float64(interval) * factor)
+	}
+	return now.Add(interval)
+}
+
+func (s *PollScheduler) rollDayLocked(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(s.day) {
+		s.day = day
+		s.used = 0
+	}
+}
+
+func (s *PollScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func positiveOr(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var pollStart = time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+
+func TestPollPolicyInterval(t *testing.T) {
+	now := pollStart.Add(12 * time.Hour)
+	tests := []struct {
+		name   string
+		data   FixtureData
+		want   time.Duration
+		active bool
+	}{
+		{"in play", FixtureData{GameStatus: StatusFirstHalf}, 15 * time.Second, true},
+		{"half time", FixtureData{GameStatus: StatusHalfTime}, time.Minute, true},
+		{"postponed", FixtureData{GameStatus: StatusPostponed, Date: now.Add(-time.Hour)}, 12 * time.Hour, true},
+		{"abandoned", FixtureData{GameStatus: StatusAbandoned, Date: now.Add(-time.Hour)}, 12 * time.Hour, true},
+		{"overdue", FixtureData{GameStatus: StatusNotStarted, Date: now.Add(-time.Minute)}, time.Minute, true},
+		{"pre-match", FixtureData{GameStatus: StatusNotStarted, Date: now.Add(time.Hour)}, 10 * time.Minute, true},
+		{"just before kickoff", FixtureData{GameStatus: StatusNotStarted, Date: now.Add(3 * time.Minute)}, 3 * time.Minute, true},
+		{"approaching window", FixtureData{GameStatus: StatusNotStarted, Date: now.Add(3 * time.Hour)}, time.Hour, true},
+		{"far future", FixtureData{GameStatus: StatusNotStarted, Date: now.Add(72 * time.Hour)}, 12 * time.Hour, true},
+		{"finished", FixtureData{GameStatus: StatusFinished}, 0, false},
+		{"finished flag", FixtureData{GameStatus: StatusSecondHalf, Finished: true}, 0, false},
+		{"cancelled", FixtureData{GameStatus: StatusCancelled}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, active := DefaultPollPolicy().Interval(tt.data, now)
+			if got != tt.want || active != tt.active {
+				t.Fatalf("Interval = %v, %v, want %v, %v", got, active, tt.want, tt.active)
+			}
+		})
+	}
+}
+
+func TestPollPolicyZeroValueUsesDefaults(t *testing.T) {
+	got, _ := PollPolicy{}.Interval(FixtureData{GameStatus: StatusFirstHalf}, pollStart)
+	if got != DefaultPollPolicy().InPlayInterval {
+		t.Fatalf("in-play interval = %v, want default", got)
+	}
+	s := NewPollScheduler(PollPolicy{ErrorInterval: -time.Second}, nil, nil)
+	if s.Policy.ErrorInterval != DefaultPollPolicy().ErrorInterval {
+		t.Fatalf("ErrorInterval = %v, want default", s.Policy.ErrorInterval)
+	}
+}
+
+func TestPollSchedulerStretchesToBudget(t *testing.T) {
+	clock := NewManualClock(pollStart)
+	policy := DefaultPollPolicy()
+	policy.DailyBudget = 100
+	s := NewPollScheduler(policy, nil, clock)
+
+	// One in-play fixture polled every 15s would need 5760 fetches over the
+	// day, 57.6 times the budget, so its interval is stretched as much.
+	s.Track("1", FixtureData{GameStatus: StatusFirstHalf})
+	next, _ := s.NextPoll("1")
+	if want := pollStart.Add(864 * time.Second); !next.Equal(want) {
+		t.Fatalf("next poll = %v, want %v", next, want)
+	}
+
+	policy.DailyBudget = 0
+	unlimited := NewPollScheduler(policy, nil, clock)
+	unlimited.Track("1", FixtureData{GameStatus: StatusFirstHalf})
+	next, _ = unlimited.NextPoll("1")
+	if want := pollStart.Add(15 * time.Second); !next.Equal(want) {
+		t.Fatalf("unlimited next poll = %v, want %v", next, want)
+	}
+}
+
+func TestPollSchedulerBudgetCapAndDayRollover(t *testing.T) {
+	clock := NewManualClock(pollStart)
+	policy := DefaultPollPolicy()
+	policy.DailyBudget = 3
+	var fetches int
+	fetch := func(context.Context, string) (FixtureData, error) {
+		fetches++
+		return FixtureData{}, errors.New("upstream down")
+	}
+	s := NewPollScheduler(policy, fetch, clock)
+	s.Track("1", FixtureData{GameStatus: StatusFirstHalf})
+
+	// Failed fetches are retried after ErrorInterval until the budget is spent.
+	next, _ := s.NextPoll("1")
+	clock.Set(next)
+	for i := 1; i <= 4; i++ {
+		n, _ := s.RunDue(context.Background())
+		if want := min(i, 3); fetches != want {
+			t.Fatalf("after run %d: fetches = %d, want %d", i, fetches, want)
+		}
+		if i <= 3 && n != 1 || i == 4 && n != 0 {
+			t.Fatalf("run %d polled %d", i, n)
+		}
+		clock.Advance(policy.ErrorInterval)
+	}
+	if used := s.Used(); used != 3 {
+		t.Fatalf("Used = %d, want 3", used)
+	}
+	tomorrow := pollStart.Add(24 * time.Hour)
+	if next, _ := s.NextPoll("1"); !next.Equal(tomorrow) {
+		t.Fatalf("next poll = %v, want %v", next, tomorrow)
+	}
+
+	clock.Set(tomorrow)
+	if used := s.Used(); used != 0 {
+		t.Fatalf("Used after rollover = %d, want 0", used)
+	}
+	if n, _ := s.RunDue(context.Background()); n != 1 || fetches != 4 {
+		t.Fatalf("after rollover polled %d, fetches = %d", n, fetches)
+	}
+}
+
+func TestPollSchedulerRunFollowsClock(t *testing.T) {
+	clock := NewManualClock(pollStart)
+	var fetches atomic.Int32
+	polled := make(chan struct{}, 8)
+	fetch := func(context.Context, string) (FixtureData, error) {
+		fetches.Add(1)
+		polled <- struct{}{}
+		return FixtureData{GameStatus: StatusSecondHalf}, nil
+	}
+	// A zero-value policy must not poll in a hot loop.
+	s := NewPollScheduler(PollPolicy{}, fetch, clock)
+	s.Track("1", FixtureData{GameStatus: StatusFirstHalf})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	for i := 1; i <= 3; i++ {
+		waitForWaiter(t, clock)
+		if got := fetches.Load(); got != int32(i-1) {
+			t.Fatalf("fetches before advance %d = %d", i, got)
+		}
+		next, _ := s.NextPoll("1")
+		clock.Set(next)
+		select {
+		case <-polled:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("poll %d did not happen", i)
+		}
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run = %v", err)
+	}
+	if got := fetches.Load(); got != 3 {
+		t.Fatalf("fetches = %d, want 3", got)
+	}
+}
+
+// waitForWaiter blocks until a goroutine is waiting on the clock.
+func waitForWaiter(t *testing.T, clock *ManualClock) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("nothing is waiting on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
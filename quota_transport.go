@@ -0,0 +1,418 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Headers API-Football uses to authenticate requests and report the remaining quota of an API key.
+const (
+	HeaderAPIKey             = "x-apisports-key"
+	HeaderDailyLimit         = "x-ratelimit-requests-limit"
+	HeaderDailyRemaining     = "x-ratelimit-requests-remaining"
+	HeaderPerMinuteLimit     = "X-RateLimit-Limit"
+	HeaderPerMinuteRemaining = "X-RateLimit-Remaining"
+)
+
+const (
+	headerRetryAfter        = "Retry-After"
+	defaultQuotaRetries     = 3
+	defaultQuotaBaseBackoff = time.Second
+	defaultQuotaMaxBackoff  = 30 * time.Second
+	quotaUnknown            = -1
+	// minQuotaWait is the shortest wait for quota, so that a window that has
+	// already ended never makes acquire spin.
+	minQuotaWait = 100 * time.Millisecond
+)
+
+// ErrQuotaExhausted is returned when every API key has used up its daily quota.
+// It matches ErrQuotaExceeded, but other quota errors, such as a per-minute
+// limit, do not match it.
+var ErrQuotaExhausted = QuotaExceeded("api-football daily quota exhausted")
+
+// QuotaState reports the known quota of a single API key. Limits and remaining
+// counts are -1 while they are neither configured nor learned from a response.
+type QuotaState struct {
+	Key                string    `json:"key"`
+	PerMinuteLimit     int       `json:"per_minute_limit"`
+	PerMinuteRemaining int       `json:"per_minute_remaining"`
+	DailyLimit         int       `json:"daily_limit"`
+	DailyRemaining     int       `json:"daily_remaining"`
+	MinuteResetAt      time.Time `json:"minute_reset_at"`
+	DayResetAt         time.Time `json:"day_reset_at"`
+	CoolingDownUntil   time.Time `json:"cooling_down_until"`
+}
+
+// QuotaTransport is an http.RoundTripper for API-Football that spreads requests
+// across API keys, keeps each key within its per-minute and per-day budget,
+// learns the real limits from the x-ratelimit response headers and retries
+// 429 and 5xx responses with exponential backoff. It is safe for concurrent use.
+type QuotaTransport struct {
+	// Base performs the actual requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+	// Keys are the API keys rotated between requests.
+	Keys []string
+	// KeyHeader is the header carrying the API key. Defaults to x-apisports-key.
+	KeyHeader string
+	// PerMinute and PerDay cap requests per key. Zero means the limits are
+	// learned from the response headers only.
+	PerMinute int
+	PerDay    int
+	// MaxRetries is the number of retries after a 429 or 5xx response.
+	MaxRetries int
+	// BaseBackoff and MaxBackoff bound the exponential delay between retries.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Clock       Clock
+
+	mu     sync.Mutex
+	quotas []*keyQuota
+	next   int
+	wake   chan struct{}
+}
+
+type keyQuota struct {
+	key             string
+	minuteStart     time.Time
+	minuteLimit     int
+	minuteRemaining int
+	day             time.Time
+	dayLimit        int
+	dayRemaining    int
+	coolUntil       time.Time
+}
+
+// NewQuotaTransport returns a QuotaTransport rotating the given API keys over http.DefaultTransport.
+func NewQuotaTransport(keys ...string) *QuotaTransport {
+	return &QuotaTransport{
+		Base:        http.DefaultTransport,
+		Keys:        keys,
+		KeyHeader:   HeaderAPIKey,
+		MaxRetries:  defaultQuotaRetries,
+		BaseBackoff: defaultQuotaBaseBackoff,
+		MaxBackoff:  defaultQuotaMaxBackoff,
+		Clock:       SystemClock{},
+	}
+}
+
+// RoundTrip sends the request with the next API key that has quota left,
+// waiting for a per-minute window to reset when all keys are busy.
+func (t *QuotaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	retryable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		q, err := t.acquire(req)
+		if err != nil {
+			return nil, err
+		}
+
+		out := req.Clone(ctx)
+		if attempt > 0 && req.GetBody != nil {
+			if out.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		if q != nil {
+			out.Header.Set(t.keyHeader(), q.key)
+		}
+
+		resp, err := t.base().RoundTrip(out)
+		if err != nil {
+			return nil, err
+		}
+		t.observe(q, resp)
+
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		if !retry || !retryable || attempt >= t.maxRetries() {
+			return resp, nil
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.clock().After(t.backoff(attempt, resp)):
+		}
+	}
+}
+
+// Quota returns the current quota state of every API key.
+func (t *QuotaTransport) Quota() []QuotaState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock().Now()
+	t.initLocked()
+
+	out := make([]QuotaState, 0, len(t.quotas))
+	for _, q := range t.quotas {
+		q.roll(now)
+		state := QuotaState{
+			Key:                maskKey(q.key),
+			PerMinuteLimit:     q.minuteLimit,
+			PerMinuteRemaining: q.minuteRemaining,
+			DailyLimit:         q.dayLimit,
+			DailyRemaining:     q.dayRemaining,
+			DayResetAt:         q.day.Add(24 * time.Hour),
+			CoolingDownUntil:   q.coolUntil,
+		}
+		if !q.minuteStart.IsZero() {
+			state.MinuteResetAt = q.minuteStart.Add(time.Minute)
+		}
+		out = append(out, state)
+	}
+	return out
+}
+
+// acquire reserves one request on the next key with quota left. It returns a
+// nil quota when no keys are configured and the request is sent unchanged.
+func (t *QuotaTransport) acquire(req *http.Request) (*keyQuota, error) {
+	ctx := req.Context()
+	for {
+		t.mu.Lock()
+		t.initLocked()
+		if len(t.quotas) == 0 {
+			t.mu.Unlock()
+			return nil, nil
+		}
+
+		now := t.clock().Now()
+		var (
+			wakeAt    time.Time
+			exhausted = true
+		)
+		for i := range t.quotas {
+			idx := (t.next + i) % len(t.quotas)
+			q := t.quotas[idx]
+			q.roll(now)
+
+			if q.dayRemaining == 0 {
+				continue
+			}
+			exhausted = false
+
+			ready := q.minuteRemaining != 0 && !q.coolUntil.After(now)
+			if ready {
+				q.reserve(now)
+				t.next = idx + 1
+				t.mu.Unlock()
+				return q, nil
+			}
+
+			at := q.minuteStart.Add(time.Minute)
+			if q.coolUntil.After(now) && (q.minuteRemaining != 0 || q.coolUntil.After(at)) {
+				at = q.coolUntil
+			}
+			if wakeAt.IsZero() || at.Before(wakeAt) {
+				wakeAt = at
+			}
+		}
+		wake := t.wake
+		t.mu.Unlock()
+
+		if exhausted {
+			return nil, ErrQuotaExhausted
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-wake:
+		case <-t.clock().After(max(wakeAt.Sub(now), minQuotaWait)):
+		}
+	}
+}
+
+// observe updates the key's quota from the response headers and puts the key
+// on cooldown after a 429. Configured limits cap the learned ones.
+func (t *QuotaTransport) observe(q *keyQuota, resp *http.Response) {
+	if q == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock().Now()
+	q.roll(now)
+	if q.minuteStart.IsZero() {
+		// The response outlived the window it was sent in: its counts
+		// belong to a window starting now.
+		q.minuteStart = now
+	}
+
+	h := resp.Header
+	if v, ok := headerInt(h, HeaderPerMinuteLimit); ok {
+		q.minuteLimit = capQuota(t.PerMinute, limitOrUnknown(t.PerMinute), v)
+	}
+	if v, ok := headerInt(h, HeaderPerMinuteRemaining); ok {
+		q.minuteRemaining = capQuota(t.PerMinute, q.minuteRemaining, v)
+	}
+	if v, ok := headerInt(h, HeaderDailyLimit); ok {
+		q.dayLimit = capQuota(t.PerDay, limitOrUnknown(t.PerDay), v)
+	}
+	if v, ok := headerInt(h, HeaderDailyRemaining); ok {
+		q.dayRemaining = capQuota(t.PerDay, q.dayRemaining, v)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		until := now.Add(time.Minute)
+		if d, ok := retryAfter(h, now); ok {
+			until = now.Add(d)
+		}
+		if until.After(q.coolUntil) {
+			q.coolUntil = until
+		}
+	}
+
+	// Another goroutine may be waiting for quota the headers just released.
+	close(t.wake)
+	t.wake = make(chan struct{})
+}
+
+func (t *QuotaTransport) initLocked() {
+	if t.wake == nil {
+		t.wake = make(chan struct{})
+	}
+	if len(t.quotas) == len(t.Keys) {
+		return
+	}
+	t.quotas = t.quotas[:0]
+	for _, key := range t.Keys {
+		t.quotas = append(t.quotas, &keyQuota{
+			key:             key,
+			minuteLimit:     limitOrUnknown(t.PerMinute),
+			minuteRemaining: limitOrUnknown(t.PerMinute),
+			dayLimit:        limitOrUnknown(t.PerDay),
+			dayRemaining:    limitOrUnknown(t.PerDay),
+		})
+	}
+}
+
+func (t *QuotaTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp.Header, t.clock().Now()); ok {
+		return d
+	}
+	base, max := t.BaseBackoff, t.MaxBackoff
+	if base <= 0 {
+		base = defaultQuotaBaseBackoff
+	}
+	if max <= 0 {
+		max = defaultQuotaMaxBackoff
+	}
+	d := base << uint(attempt)
+	if d <= 0 || d > max {
+		d = max
+	}
+	return d
+}
+
+func (t *QuotaTransport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+func (t *QuotaTransport) clock() Clock {
+	if t.Clock == nil {
+		return SystemClock{}
+	}
+	return t.Clock
+}
+
+func (t *QuotaTransport) keyHeader() string {
+	if t.KeyHeader == "" {
+		return HeaderAPIKey
+	}
+	return t.KeyHeader
+}
+
+func (t *QuotaTransport) maxRetries() int {
+	if t.MaxRetries < 0 {
+		return 0
+	}
+	return t.MaxRetries
+}
+
+// roll starts new minute and day windows once the previous ones have elapsed,
+// restoring the limits, which never exceed the configured caps.
+func (q *keyQuota) roll(now time.Time) {
+	if !q.minuteStart.IsZero() && !now.Before(q.minuteStart.Add(time.Minute)) {
+		q.minuteStart = time.Time{}
+		q.minuteRemaining = q.minuteLimit
+	}
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(q.day) {
+		if !q.day.IsZero() {
+			q.dayRemaining = q.dayLimit
+		}
+		q.day = day
+	}
+}
+
+func (q *keyQuota) reserve(now time.Time) {
+	if q.minuteStart.IsZero() {
+		q.minuteStart = now
+	}
+	if q.minuteRemaining > 0 {
+		q.minuteRemaining--
+	}
+	if q.dayRemaining > 0 {
+		q.dayRemaining--
+	}
+}
+
+func limitOrUnknown(limit int) int {
+	if limit <= 0 {
+		return quotaUnknown
+	}
+	return limit
+}
+
+// capQuota returns the learned value, or the current one when the configured
+// cap is lower.
+func capQuota(configured, current, learned int) int {
+	if configured <= 0 || current == quotaUnknown {
+		return learned
+	}
+	return min(current, learned)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get(headerRetryAfter)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0), true
+	}
+	at, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	return max(at.Sub(now), 0), true
+}
+
+func headerInt(h http.Header, name string) (int, bool) {
+	v := h.Get(name)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// maskKey hides all but the last four characters of an API key.
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var quotaStart = time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)
+
+// quotaServer answers with the quota headers of a fresh API-Football key.
+func quotaServer(t *testing.T, handle func(w http.ResponseWriter, n int32)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set(HeaderPerMinuteLimit, "300")
+		w.Header().Set(HeaderPerMinuteRemaining, "299")
+		w.Header().Set(HeaderDailyLimit, "7500")
+		w.Header().Set(HeaderDailyRemaining, "7499")
+		if handle != nil {
+			handle(w, n)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func quotaGet(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestQuotaTransportKeepsConfiguredCaps(t *testing.T) {
+	srv, calls := quotaServer(t, nil)
+	clock := NewManualClock(quotaStart)
+	tr := NewQuotaTransport("secret-key")
+	tr.PerMinute, tr.PerDay, tr.Clock = 2, 3, clock
+	client := &http.Client{Transport: tr}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := quotaGet(ctx, client, srv.URL); err != nil {
+			t.Fatal(err)
+		}
+	}
+	q := tr.Quota()[0]
+	if q.PerMinuteLimit != 2 || q.PerMinuteRemaining != 0 || q.DailyLimit != 3 || q.DailyRemaining != 1 {
+		t.Fatalf("quota = %+v", q)
+	}
+
+	// The per-minute cap holds the third request until the window ends.
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := quotaGet(short, client, srv.URL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("third request in the minute = %v, want deadline exceeded", err)
+	}
+
+	clock.Advance(time.Minute)
+	if err := quotaGet(ctx, client, srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	if err := quotaGet(ctx, client, srv.URL); !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("fourth request of the day = %v, want ErrQuotaExhausted", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("server saw %d requests, want 3", n)
+	}
+	if q := tr.Quota()[0]; q.PerMinuteRemaining != 1 || q.DailyRemaining != 0 {
+		t.Fatalf("quota = %+v", q)
+	}
+}
+
+func TestQuotaTransportLearnsLimits(t *testing.T) {
+	srv, _ := quotaServer(t, nil)
+	tr := NewQuotaTransport("secret-key")
+	tr.Clock = NewManualClock(quotaStart)
+	if err := quotaGet(context.Background(), &http.Client{Transport: tr}, srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	q := tr.Quota()[0]
+	if q.PerMinuteLimit != 300 || q.PerMinuteRemaining != 299 || q.DailyLimit != 7500 || q.DailyRemaining != 7499 {
+		t.Fatalf("quota = %+v", q)
+	}
+	if q.Key != "****-key" {
+		t.Fatalf("key = %q, want masked", q.Key)
+	}
+}
+
+func TestQuotaTransportSlowResponseAfterWindow(t *testing.T) {
+	clock := NewManualClock(quotaStart)
+	srv, calls := quotaServer(t, func(w http.ResponseWriter, n int32) {
+		if n == 1 {
+			// The response arrives after the window the request was sent in.
+			clock.Advance(61 * time.Second)
+			w.Header().Set(HeaderPerMinuteRemaining, "0")
+		}
+	})
+	tr := NewQuotaTransport("secret-key")
+	tr.Clock = clock
+	client := &http.Client{Transport: tr}
+	if err := quotaGet(context.Background(), client, srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	reset := quotaStart.Add(61 * time.Second).Add(time.Minute)
+	if q := tr.Quota()[0]; q.PerMinuteRemaining != 0 || !q.MinuteResetAt.Equal(reset) {
+		t.Fatalf("quota = %+v, want no quota until %v", q, reset)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- quotaGet(context.Background(), client, srv.URL) }()
+	waitForWaiter(t, clock)
+	select {
+	case err := <-done:
+		t.Fatalf("request sent before the window reset: %v", err)
+	default:
+	}
+	clock.Set(reset)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("server saw %d requests, want 2", n)
+	}
+}
+
+func TestQuotaTransportRetryAfterDate(t *testing.T) {
+	clock := NewManualClock(quotaStart)
+	srv, calls := quotaServer(t, func(w http.ResponseWriter, n int32) {
+		if n == 1 {
+			w.Header().Set(headerRetryAfter, quotaStart.Add(30*time.Second).Format(http.TimeFormat))
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	tr := NewQuotaTransport("secret-key")
+	tr.Clock = clock
+	client := &http.Client{Transport: tr}
+
+	done := make(chan error, 1)
+	go func() { done <- quotaGet(context.Background(), client, srv.URL) }()
+	waitForWaiter(t, clock)
+	if q := tr.Quota()[0]; !q.CoolingDownUntil.Equal(quotaStart.Add(30 * time.Second)) {
+		t.Fatalf("cooling down until %v, want 30s", q.CoolingDownUntil)
+	}
+	clock.Advance(30 * time.Second)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("server saw %d requests, want 2", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := quotaStart
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-5", 0, true},
+		{now.Add(45 * time.Second).Format(http.TimeFormat), 45 * time.Second, true},
+		{now.Add(-time.Hour).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.value != "" {
+			h.Set(headerRetryAfter, tt.value)
+		}
+		got, ok := retryAfter(h, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fixtureDateLayout is the date format API-Football expects in date parameters.
+const fixtureDateLayout = "2006-01-02"
+
+// Validate checks that the request names a league and a season in one of the forms ParseSeason accepts.
+func (r LeagueRequest) Validate() error {
+	fields := requireNumeric(nil, "league_id", r.LeagueID)
+	if r.Season == "" {
+		fields = append(fields, FieldError{Field: "season", Message: "is required"})
+	} else if _, err := ParseSeason(r.Season); err != nil {
+		fields = append(fields, FieldError{Field: "season", Message: "must be a year such as 2023 or 2023/2024"})
+	}
+	return invalidIfAny(fields)
+}
+
+// Validate checks that the request names a fixture.
+func (r FixtureRequest) Validate() error {
+	return invalidIfAny(requireNumeric(nil, "fixture_id", r.FixtureID))
+}
+
+// Validate checks that the request has a YYYY-MM-DD date, names a league and
+// gives the timezone the date is a calendar day in.
+func (r GetFixturesByDateAndLeagueRequest) Validate() error {
+	var fields []FieldError
+	if r.Date == "" {
+		fields = append(fields, FieldError{Field: "date", Message: "is required"})
+	} else if _, err := time.Parse(fixtureDateLayout, r.Date); err != nil {
+		fields = append(fields, FieldError{Field: "date", Message: "must be formatted as YYYY-MM-DD"})
+	}
+	fields = requireNumeric(fields, "league", r.League)
+	if strings.TrimSpace(r.Timezone) == "" {
+		fields = append(fields, FieldError{Field: "timezone", Message: "is required"})
+	} else {
+		fields = requireTimezone(fields, "timezone", r.Timezone)
+	}
+	return invalidIfAny(fields)
+}
+
+// Validate checks that the request names a league and at least one numeric season.
+func (r BackfillRequest) Validate() error {
+	fields := requireNumeric(nil, "league_id", r.LeagueID)
+	if len(r.Seasons) == 0 {
+		fields = append(fields, FieldError{Field: "seasons", Message: "at least one season is required"})
+	}
+	for i, season := range r.Seasons {
+		fields = requireNumeric(fields, "seasons["+strconv.Itoa(i)+"]", season)
+	}
+	return invalidIfAny(fields)
+}
+
+func requireNumeric(fields []FieldError, name, value string) []FieldError {
+	if value == "" {
+		return append(fields, FieldError{Field: name, Message: "is required"})
+	}
+	if _, err := strconv.Atoi(value); err != nil {
+		return append(fields, FieldError{Field: name, Message: "must be numeric"})
+	}
+	return fields
+}
+
+func requireTimezone(fields []FieldError, name, value string) []FieldError {
+	if _, err := ResolveLocation(value); err != nil {
+		return append(fields, FieldError{Field: name, Message: "must be an IANA timezone name"})
+	}
+	return fields
+}
+
+// invalidIfAny returns an InvalidRequest error for fields, or nil when there are none.
+func invalidIfAny(fields []FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return InvalidRequest(fields...)
+}
@@ -0,0 +1,45 @@
+package client
+
+import "time"
+
+// LatestFixtures returns fixtures with a single version of each FixtureID,
+// the one updated last, so that a rescheduled fixture counts at its new Date
+// only. Versions with the same UpdateAt are resolved in favour of the later
+// one in fixtures. Order follows each fixture's first appearance.
+func LatestFixtures(fixtures []GeneralFixtureData) []GeneralFixtureData {
+	index := make(map[string]int, len(fixtures))
+	out := make([]GeneralFixtureData, 0, len(fixtures))
+	for _, g := range fixtures {
+		i, ok := index[g.FixtureID]
+		switch {
+		case !ok || g.FixtureID == "":
+			index[g.FixtureID] = len(out)
+			out = append(out, g)
+		case !g.FixtureData.UpdateAt.Before(out[i].FixtureData.UpdateAt):
+			out[i] = g
+		}
+	}
+	return out
+}
+
+// Schedule returns the fixtures still to be played that kick off in
+// [from, to), ordered by date, with zero bounds left open. Postponed and
+// abandoned fixtures are listed whatever their last known date, as they await
+// a new one; cancelled, played and awarded fixtures are left out.
+func Schedule(fixtures []GeneralFixtureData, from, to time.Time) []GeneralFixtureData {
+	var out []GeneralFixtureData
+	for _, g := range LatestFixtures(fixtures) {
+		f := g.FixtureData
+		outcome := f.Outcome()
+		if !outcome.InSchedule() {
+			continue
+		}
+		pending := outcome == OutcomePostponed || outcome == OutcomeAbandoned
+		if !pending && ((!from.IsZero() && f.Date.Before(from)) || (!to.IsZero() && !f.Date.Before(to))) {
+			continue
+		}
+		out = append(out, g)
+	}
+	sortFixtures(out)
+	return out
+}
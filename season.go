@@ -0,0 +1,152 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// splitSeasonStartMonth is the month split-year seasons are taken to start in.
+const splitSeasonStartMonth = time.July
+
+// Season identifies a league season. Calendar-year leagues (MLS, Brasileirão)
+// play a season within Year, split-year leagues start in Year and finish in
+// Year+1. API-Football identifies both by Year alone.
+type Season struct {
+	Year  int  `json:"year" bson:"year"`
+	Split bool `json:"split" bson:"split"`
+}
+
+// ParseSeason parses "2023" as a calendar-year season and "2023/2024",
+// "2023/24" or "2023-24" as a split-year season.
+func ParseSeason(s string) (Season, error) {
+	s = strings.TrimSpace(s)
+	first, second, split := strings.Cut(s, "/")
+	if !split {
+		first, second, split = strings.Cut(s, "-")
+	}
+
+	year, err := strconv.Atoi(first)
+	if err != nil || len(first) != 4 {
+		return Season{}, fmt.Errorf("invalid season %q", s)
+	}
+	if !split {
+		return Season{Year: year}, nil
+	}
+
+	end, err := strconv.Atoi(second)
+	switch {
+	case err != nil:
+		return Season{}, fmt.Errorf("invalid season %q", s)
+	case len(second) == 2:
+		end += year / 100 * 100
+		if end < year {
+			end += 100
+		}
+	case len(second) != 4:
+		return Season{}, fmt.Errorf("invalid season %q", s)
+	}
+	if end != year+1 {
+		return Season{}, fmt.Errorf("invalid season %q: must span consecutive years", s)
+	}
+	return Season{Year: year, Split: true}, nil
+}
+
+// ParseLeagueSeason parses a season for a league whose format is known, so
+// that API-Football's numeric form "2023" is read as 2023/2024 for split-year leagues.
+func ParseLeagueSeason(s string, split bool) (Season, error) {
+	season, err := ParseSeason(s)
+	if err != nil {
+		return Season{}, err
+	}
+	if season.Split && !split {
+		return Season{}, fmt.Errorf("season %q spans two years but the league plays calendar years", s)
+	}
+	season.Split = split
+	return season, nil
+}
+
+// SeasonAt returns the season in progress at t for a calendar-year or split-year league.
+func SeasonAt(t time.Time, split bool) Season {
+	if split && t.Month() < splitSeasonStartMonth {
+		return Season{Year: t.Year() - 1, Split: true}
+	}
+	return Season{Year: t.Year(), Split: split}
+}
+
+// String formats the season for display, as "2023" or "2023/24".
+func (s Season) String() string {
+	if s.Split {
+		return fmt.Sprintf("%d/%02d", s.Year, (s.Year+1)%100)
+	}
+	return strconv.Itoa(s.Year)
+}
+
+// Long formats the season with both years in full, as "2023" or "2023/2024".
+func (s Season) Long() string {
+	if s.Split {
+		return fmt.Sprintf("%d/%d", s.Year, s.Year+1)
+	}
+	return strconv.Itoa(s.Year)
+}
+
+// APIParam formats the season as API-Football's numeric season parameter.
+func (s Season) APIParam() string {
+	return strconv.Itoa(s.Year)
+}
+
+// Start returns the nominal first day of the season in UTC: 1 January for
+// calendar-year seasons and 1 July for split-year seasons.
+func (s Season) Start() time.Time {
+	if s.Split {
+		return time.Date(s.Year, splitSeasonStartMonth, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(s.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the nominal end of the season in UTC, exclusive.
+func (s Season) End() time.Time {
+	return s.Start().AddDate(1, 0, 0)
+}
+
+// Contains reports whether t falls within the season's nominal dates.
+func (s Season) Contains(t time.Time) bool {
+	return !t.Before(s.Start()) && t.Before(s.End())
+}
+
+// Compare orders seasons by their start date, returning -1, 0 or 1.
+func (s Season) Compare(other Season) int {
+	return s.Start().Compare(other.Start())
+}
+
+// Before reports whether s starts before other.
+func (s Season) Before(other Season) bool {
+	return s.Compare(other) < 0
+}
+
+// Prev returns the previous season in the same format.
+func (s Season) Prev() Season {
+	return Season{Year: s.Year - 1, Split: s.Split}
+}
+
+// Next returns the following season in the same format.
+func (s Season) Next() Season {
+	return Season{Year: s.Year + 1, Split: s.Split}
+}
+
+// ParsedSeason returns the league's Season, preferring SeasonNumber when Season is not parseable.
+func (l League) ParsedSeason() (Season, error) {
+	if season, err := ParseSeason(l.Season); err == nil {
+		return season, nil
+	}
+	if l.SeasonNumber > 0 {
+		return Season{Year: l.SeasonNumber}, nil
+	}
+	return Season{}, fmt.Errorf("invalid season %q", l.Season)
+}
+
+// ParsedSeason returns the request's Season.
+func (r LeagueRequest) ParsedSeason() (Season, error) {
+	return ParseSeason(r.Season)
+}
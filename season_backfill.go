@@ -0,0 +1,355 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const defaultCheckpointEvery = 10
+
+// Reasons a backfill run stopped before completing every season.
+const (
+	BackfillStoppedQuota     = "quota_exhausted"
+	BackfillStoppedBudget    = "request_budget_reached"
+	BackfillStoppedCancelled = "cancelled"
+)
+
+// BackfillRequest represents the request to pull the history of a league over several seasons.
+type BackfillRequest struct {
+	LeagueID string   `json:"league_id"`
+	Seasons  []string `json:"seasons"`
+}
+
+// NewBackfillRequest returns a BackfillRequest for the season in req and the
+// count-1 seasons before it, newest first.
+func NewBackfillRequest(req LeagueRequest, count int) (BackfillRequest, error) {
+	if err := req.Validate(); err != nil {
+		return BackfillRequest{}, err
+	}
+	if count <= 0 {
+		return BackfillRequest{}, InvalidRequest(FieldError{Field: "count", Message: "must be positive"})
+	}
+	season, _ := req.ParsedSeason()
+	out := BackfillRequest{LeagueID: req.LeagueID}
+	for i := 0; i < count; i++ {
+		out.Seasons = append(out.Seasons, season.APIParam())
+		season = season.Prev()
+	}
+	return out, nil
+}
+
+// BackfillSource lists and fetches the fixtures of a league season from the upstream API.
+type BackfillSource interface {
+	FixtureIDs(ctx context.Context, leagueID, season string) ([]string, error)
+	Fixture(ctx context.Context, fixtureID string) (GeneralFixtureData, error)
+}
+
+// BackfillResult reports the outcome of a backfill run.
+type BackfillResult struct {
+	LeagueID      string                 `json:"league_id"`
+	Seasons       []SeasonBackfillResult `json:"seasons"`
+	Requests      int                    `json:"requests"`
+	Completed     bool                   `json:"completed"`
+	StoppedReason string                 `json:"stopped_reason,omitempty"`
+}
+
+// SeasonBackfillResult reports the outcome of a backfill run for a single season.
+// Skipped counts fixtures already stored by a previous, interrupted run.
+type SeasonBackfillResult struct {
+	Season    string            `json:"season"`
+	Total     int               `json:"total"`
+	Stored    int               `json:"stored"`
+	Skipped   int               `json:"skipped"`
+	Failed    int               `json:"failed"`
+	Failures  []BackfillFailure `json:"failures,omitempty"`
+	Completed bool              `json:"completed"`
+}
+
+// BackfillFailure records a fixture that could not be fetched or stored.
+type BackfillFailure struct {
+	FixtureID string `json:"fixture_id"`
+	Error     string `json:"error"`
+}
+
+// Response summarises the result as a LeagueAddResponse.
+func (r BackfillResult) Response() LeagueAddResponse {
+	var stored, skipped, failed int
+	for _, s := range r.Seasons {
+		stored += s.Stored
+		skipped += s.Skipped
+		failed += s.Failed
+	}
+	msg := fmt.Sprintf("league %s: %d seasons, %d fixtures stored, %d skipped, %d failed",
+		r.LeagueID, len(r.Seasons), stored, skipped, failed)
+	if !r.Completed {
+		msg += " (incomplete"
+		if r.StoppedReason != "" {
+			msg += ": " + r.StoppedReason
+		}
+		msg += ")"
+	}
+	return LeagueAddResponse{Message: msg}
+}
+
+// BackfillCheckpoint is the persisted progress of a league backfill.
+type BackfillCheckpoint struct {
+	LeagueID  string                     `json:"league_id" bson:"league_id"`
+	Seasons   map[string]*SeasonProgress `json:"seasons" bson:"seasons"`
+	UpdatedAt time.Time                  `json:"updated_at" bson:"updated_at"`
+}
+
+// SeasonProgress is the persisted progress of a single season within a backfill.
+type SeasonProgress struct {
+	FixtureIDs []string          `json:"fixture_ids" bson:"fixture_ids"`
+	Done       map[string]bool   `json:"done" bson:"done"`
+	Failed     map[string]string `json:"failed,omitempty" bson:"failed,omitempty"`
+	Completed  bool              `json:"completed" bson:"completed"`
+}
+
+// CheckpointStore persists backfill checkpoints by league.
+type CheckpointStore interface {
+	LoadCheckpoint(ctx context.Context, leagueID string) (BackfillCheckpoint, bool, error)
+	SaveCheckpoint(ctx context.Context, checkpoint BackfillCheckpoint) error
+}
+
+// FileCheckpointStore stores each league's checkpoint as a JSON file in Dir.
+type FileCheckpointStore struct {
+	Dir string
+}
+
+// LoadCheckpoint reads the checkpoint of a league, reporting false when none exists.
+func (s FileCheckpointStore) LoadCheckpoint(_ context.Context, leagueID string) (BackfillCheckpoint, bool, error) {
+	raw, err := os.ReadFile(s.path(leagueID))
+	if errors.Is(err, os.ErrNotExist) {
+		return BackfillCheckpoint{}, false, nil
+	}
+	if err != nil {
+		return BackfillCheckpoint{}, false, err
+	}
+	var cp BackfillCheckpoint
+	if err := json.Unmarshal(raw, &cp); err != nil {
+		return BackfillCheckpoint{}, false, fmt.Errorf("decode checkpoint for league %s: %w", leagueID, err)
+	}
+	return cp, true, nil
+}
+
+// SaveCheckpoint writes the checkpoint atomically so a crash never leaves a partial file.
+func (s FileCheckpointStore) SaveCheckpoint(_ context.Context, checkpoint BackfillCheckpoint) error {
+	raw, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.Dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(checkpoint.LeagueID))
+}
+
+func (s FileCheckpointStore) path(leagueID string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(leagueID)
+	return filepath.Join(s.Dir, "backfill-"+name+".json")
+}
+
+// Backfill pulls the fixtures of several seasons of a league into a
+// FixtureRepository, checkpointing progress so an interrupted run resumes
+// where it stopped instead of starting over.
+type Backfill struct {
+	Source      BackfillSource
+	Repository  FixtureRepository
+	Checkpoints CheckpointStore
+	Clock       Clock
+	// RequestBudget caps the upstream requests made by a single run. Zero means unlimited.
+	RequestBudget int
+	// CheckpointEvery is the number of fixtures processed between checkpoints.
+	CheckpointEvery int
+}
+
+// Run backfills the requested seasons. A run stopped by the request budget,
+// an exhausted upstream quota or cancellation returns the partial result with
+// Completed false and a nil error; running it again resumes from the checkpoint.
+// Fixtures that failed are recorded and retried by the next run.
+func (b *Backfill) Run(ctx context.Context, req BackfillRequest) (BackfillResult, error) {
+	result := BackfillResult{LeagueID: req.LeagueID}
+
+	cp, found, err := b.Checkpoints.LoadCheckpoint(ctx, req.LeagueID)
+	if err != nil {
+		return result, fmt.Errorf("load checkpoint: %w", err)
+	}
+	if !found || cp.Seasons == nil {
+		cp = BackfillCheckpoint{LeagueID: req.LeagueID, Seasons: make(map[string]*SeasonProgress)}
+	}
+
+	run := backfillRun{Backfill: b, checkpoint: &cp, result: &result}
+	for _, season := range req.Seasons {
+		stop, err := run.season(ctx, season)
+		if err != nil {
+			return result, err
+		}
+		if stop != "" {
+			result.StoppedReason = stop
+			return result, run.save(ctx)
+		}
+	}
+	result.Completed = true
+	for _, s := range result.Seasons {
+		if !s.Completed {
+			result.Completed = false
+		}
+	}
+	return result, run.save(ctx)
+}
+
+// backfillRun carries the state of a single Backfill.Run call.
+type backfillRun struct {
+	*Backfill
+	checkpoint *BackfillCheckpoint
+	result     *BackfillResult
+	processed  int
+}
+
+// season backfills a single season, returning a non-empty stop reason when the run must end early.
+func (r *backfillRun) season(ctx context.Context, season string) (string, error) {
+	progress := r.checkpoint.Seasons[season]
+	if progress == nil {
+		progress = &SeasonProgress{}
+		r.checkpoint.Seasons[season] = progress
+	}
+	if progress.Done == nil {
+		progress.Done = make(map[string]bool)
+	}
+
+	r.result.Seasons = append(r.result.Seasons, SeasonBackfillResult{Season: season})
+	res := &r.result.Seasons[len(r.result.Seasons)-1]
+
+	if progress.FixtureIDs == nil {
+		if stop := r.spend(ctx); stop != "" {
+			return stop, nil
+		}
+		ids, err := r.Source.FixtureIDs(ctx, r.checkpoint.LeagueID, season)
+		if stop := stopReason(ctx, err); stop != "" {
+			return stop, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("list fixtures of season %s: %w", season, err)
+		}
+		progress.FixtureIDs = append([]string{}, ids...)
+		if err := r.save(ctx); err != nil {
+			return "", err
+		}
+	}
+	res.Total = len(progress.FixtureIDs)
+
+	for _, id := range progress.FixtureIDs {
+		if progress.Done[id] {
+			res.Skipped++
+			continue
+		}
+		if stop := r.spend(ctx); stop != "" {
+			return stop, nil
+		}
+
+		err := r.fixture(ctx, id)
+		if stop := stopReason(ctx, err); stop != "" {
+			return stop, nil
+		}
+		if err != nil {
+			res.Failed++
+			res.Failures = append(res.Failures, BackfillFailure{FixtureID: id, Error: err.Error()})
+			if progress.Failed == nil {
+				progress.Failed = make(map[string]string)
+			}
+			progress.Failed[id] = err.Error()
+		} else {
+			res.Stored++
+			progress.Done[id] = true
+			delete(progress.Failed, id)
+		}
+
+		r.processed++
+		if r.processed%r.checkpointEvery() == 0 {
+			if err := r.save(ctx); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	progress.Completed = res.Failed == 0
+	res.Completed = progress.Completed
+	return "", r.save(ctx)
+}
+
+func (r *backfillRun) fixture(ctx context.Context, fixtureID string) error {
+	f, err := r.Source.Fixture(ctx, fixtureID)
+	if err != nil {
+		return err
+	}
+	if f.FixtureID == "" {
+		f.FixtureID = fixtureID
+	}
+	if f.LeagueID == "" {
+		f.LeagueID = r.checkpoint.LeagueID
+	}
+	return r.Repository.UpsertFixture(ctx, f)
+}
+
+// spend accounts for one upstream request, returning a stop reason when none may be made.
+func (r *backfillRun) spend(ctx context.Context) string {
+	if ctx.Err() != nil {
+		return BackfillStoppedCancelled
+	}
+	if r.RequestBudget > 0 && r.result.Requests >= r.RequestBudget {
+		return BackfillStoppedBudget
+	}
+	r.result.Requests++
+	return ""
+}
+
+func (r *backfillRun) save(ctx context.Context) error {
+	clock := r.Clock
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	r.checkpoint.UpdatedAt = clock.Now()
+	// Save even when ctx is cancelled so the progress made so far is kept.
+	if err := r.Checkpoints.SaveCheckpoint(context.WithoutCancel(ctx), *r.checkpoint); err != nil {
+		return fmt.Errorf("save checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *backfillRun) checkpointEvery() int {
+	if r.CheckpointEvery <= 0 {
+		return defaultCheckpointEvery
+	}
+	return r.CheckpointEvery
+}
+
+// stopReason maps errors that should pause the backfill rather than fail it.
+func stopReason(ctx context.Context, err error) string {
+	switch {
+	case errors.Is(err, ErrQuotaExhausted):
+		return BackfillStoppedQuota
+	case ctx.Err() != nil:
+		return BackfillStoppedCancelled
+	}
+	return ""
+}
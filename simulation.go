@@ -0,0 +1,336 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	defaultSimulationRuns   = 10000
+	defaultRelegationPlaces = 3
+	// simulationChunk is the number of runs sharing one random source. Chunks
+	// are seeded by index, so results do not depend on the number of workers.
+	simulationChunk = 500
+)
+
+// OutcomeModel simulates the score of a fixture using the given random source.
+type OutcomeModel interface {
+	SimulateMatch(f FixtureData, rng *rand.Rand) (home, away int)
+}
+
+// OutcomeModelFunc adapts a function to the OutcomeModel interface.
+type OutcomeModelFunc func(f FixtureData, rng *rand.Rand) (home, away int)
+
+// SimulateMatch calls fn.
+func (fn OutcomeModelFunc) SimulateMatch(f FixtureData, rng *rand.Rand) (home, away int) {
+	return fn(f, rng)
+}
+
+// GoalRateModel draws each side's goals from a Poisson distribution with the
+// same mean for every fixture.
+type GoalRateModel struct {
+	HomeGoals float64
+	AwayGoals float64
+}
+
+// DefaultGoalRateModel returns a GoalRateModel with typical league scoring rates.
+func DefaultGoalRateModel() GoalRateModel {
+	return GoalRateModel{HomeGoals: 1.5, AwayGoals: 1.15}
+}
+
+// SimulateMatch draws a score.
+func (m GoalRateModel) SimulateMatch(_ FixtureData, rng *rand.Rand) (home, away int) {
+	return poissonSample(rng, m.HomeGoals), poissonSample(rng, m.AwayGoals)
+}
+
+// Simulator plays out the remaining fixtures of a season many times to
+// estimate each team's finishing position.
+type Simulator struct {
+	// Model simulates single fixtures. It is called from several goroutines.
+	Model OutcomeModel
+	// Runs is the number of simulated seasons.
+	Runs int
+	// Seed makes runs reproducible: the same seed, inputs and Runs give the
+	// same result whatever the number of Workers.
+	Seed int64
+	// Workers is the number of seasons simulated in parallel.
+	Workers int
+	// HeadToHead breaks ties on points with ResolveTie.
+	HeadToHead bool
+	// RelegationPlaces is the number of bottom places counted as relegation.
+	RelegationPlaces int
+	// Zones are reported with the probability of finishing within each.
+	Zones []Zone
+}
+
+// NewSimulator returns a Simulator using model with default runs, workers and
+// relegation places. A nil model uses DefaultGoalRateModel.
+func NewSimulator(model OutcomeModel) *Simulator {
+	if model == nil {
+		model = DefaultGoalRateModel()
+	}
+	return &Simulator{
+		Model:            model,
+		Runs:             defaultSimulationRuns,
+		Workers:          runtime.GOMAXPROCS(0),
+		RelegationPlaces: defaultRelegationPlaces,
+	}
+}
+
+// SimulationResult holds the finishing-position probabilities of every team.
+type SimulationResult struct {
+	Runs  int                 `json:"runs" bson:"runs"`
+	Teams []TeamProbabilities `json:"teams" bson:"teams"`
+}
+
+// TeamProbabilities gives a team's probability of each final rank, Ranks[0]
+// being first place, and of the title, a top-four finish and relegation.
+type TeamProbabilities struct {
+	TeamID         int               `json:"team_id" bson:"team_id"`
+	Team           string            `json:"team" bson:"team"`
+	Ranks          []float64         `json:"ranks" bson:"ranks"`
+	ExpectedRank   float64           `json:"expected_rank" bson:"expected_rank"`
+	ExpectedPoints float64           `json:"expected_points" bson:"expected_points"`
+	Title          float64           `json:"title" bson:"title"`
+	Top4           float64           `json:"top_4" bson:"top_4"`
+	Relegation     float64           `json:"relegation" bson:"relegation"`
+	Zones          []ZoneProbability `json:"zones,omitempty" bson:"zones,omitempty"`
+}
+
+// ZoneProbability is the probability of finishing within a zone.
+type ZoneProbability struct {
+	Zone        Zone    `json:"zone" bson:"zone"`
+	Probability float64 `json:"probability" bson:"probability"`
+}
+
+// Between returns the probability of finishing between ranks from and to, inclusive.
+func (p TeamProbabilities) Between(from, to int) float64 {
+	var sum float64
+	for rank := max(from, 1); rank <= to && rank <= len(p.Ranks); rank++ {
+		sum += p.Ranks[rank-1]
+	}
+	return sum
+}
+
+// Matrix returns the rank probability matrix, one row per team in Teams order
+// and one column per rank.
+func (r SimulationResult) Matrix() [][]float64 {
+	matrix := make([][]float64, len(r.Teams))
+	for i, t := range r.Teams {
+		matrix[i] = t.Ranks
+	}
+	return matrix
+}
+
+// Run simulates the season from standings, playing every fixture still to be
+// played in fixtures between two teams of standings. Played fixtures are only
+// used for head-to-head tiebreaks. Teams keep the order of standings.
+func (s *Simulator) Run(ctx context.Context, standings []Standings, fixtures []GeneralFixtureData) (SimulationResult, error) {
+	runs := s.Runs
+	if runs <= 0 {
+		runs = defaultSimulationRuns
+	}
+	workers := s.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	model := s.Model
+	if model == nil {
+		model = DefaultGoalRateModel()
+	}
+
+	season := newSimulatedSeason(standings, fixtures, s.HeadToHead)
+	n := len(standings)
+	total := newSimulationTally(n)
+
+	chunks := (runs + simulationChunk - 1) / simulationChunk
+	next := make(chan int)
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range next {
+				tally := newSimulationTally(n)
+				rng := rand.New(rand.NewSource(chunkSeed(s.Seed, chunk)))
+				size := min(simulationChunk, runs-chunk*simulationChunk)
+				for i := 0; i < size; i++ {
+					season.play(model, rng, tally)
+				}
+				mu.Lock()
+				total.merge(tally)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var err error
+	for chunk := 0; chunk < chunks && err == nil; chunk++ {
+		select {
+		case next <- chunk:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	close(next)
+	wg.Wait()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	return s.result(standings, total, runs), nil
+}
+
+func (s *Simulator) result(standings []Standings, tally simulationTally, runs int) SimulationResult {
+	relegation := s.RelegationPlaces
+	if relegation <= 0 {
+		relegation = defaultRelegationPlaces
+	}
+	n := len(standings)
+	result := SimulationResult{Runs: runs, Teams: make([]TeamProbabilities, n)}
+	for i, st := range standings {
+		p := TeamProbabilities{
+			TeamID:         st.TeamID,
+			Team:           st.Team,
+			Ranks:          make([]float64, n),
+			ExpectedPoints: float64(tally.points[i]) / float64(runs),
+		}
+		for rank := range p.Ranks {
+			p.Ranks[rank] = float64(tally.ranks[i][rank]) / float64(runs)
+			p.ExpectedRank += float64(rank+1) * p.Ranks[rank]
+		}
+		p.Title = p.Between(1, 1)
+		p.Top4 = p.Between(1, 4)
+		p.Relegation = p.Between(n-relegation+1, n)
+		for _, z := range s.Zones {
+			p.Zones = append(p.Zones, ZoneProbability{Zone: z, Probability: p.Between(z.From, z.To)})
+		}
+		result.Teams[i] = p
+	}
+	return result
+}
+
+// simulatedSeason holds the inputs shared by every run.
+type simulatedSeason struct {
+	standings  []Standings
+	index      map[int]int
+	remaining  []GeneralFixtureData
+	played     []GeneralFixtureData
+	headToHead bool
+}
+
+func newSimulatedSeason(standings []Standings, fixtures []GeneralFixtureData, headToHead bool) *simulatedSeason {
+	season := &simulatedSeason{
+		standings:  standings,
+		index:      make(map[int]int, len(standings)),
+		headToHead: headToHead,
+	}
+	for i, st := range standings {
+		season.index[st.TeamID] = i
+	}
+	latest := LatestFixtures(fixtures)
+	sortFixtures(latest)
+	for _, g := range latest {
+		f := g.FixtureData
+		_, home := season.index[f.HomeTeamID]
+		_, away := season.index[f.AwayTeamID]
+		switch {
+		case !home || !away:
+		case f.Outcome().InSchedule():
+			season.remaining = append(season.remaining, g)
+		case f.Outcome().CountsInStandings():
+			season.played = append(season.played, g)
+		}
+	}
+	return season
+}
+
+// play simulates one season and records the final table in tally.
+func (s *simulatedSeason) play(model OutcomeModel, rng *rand.Rand, tally simulationTally) {
+	rows := append([]Standings(nil), s.standings...)
+	var simulated []GeneralFixtureData
+	if s.headToHead {
+		simulated = append(make([]GeneralFixtureData, 0, len(s.played)+len(s.remaining)), s.played...)
+	}
+	for _, g := range s.remaining {
+		f := g.FixtureData
+		home, away := model.SimulateMatch(f, rng)
+		rows[s.index[f.HomeTeamID]].addHome(home, away)
+		rows[s.index[f.AwayTeamID]].addAway(away, home)
+		if s.headToHead {
+			g.FixtureData.GoalsHome, g.FixtureData.GoalsAway = home, away
+			g.FixtureData.GameStatus = StatusFinished
+			simulated = append(simulated, g)
+		}
+	}
+
+	if s.headToHead {
+		rows = RankStandings(rows, simulated)
+	} else {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return compareStandings(rows[i], rows[j]) < 0
+		})
+	}
+	for rank, row := range rows {
+		i := s.index[row.TeamID]
+		tally.ranks[i][rank]++
+		tally.points[i] += int64(row.Points)
+	}
+}
+
+// simulationTally counts final ranks and points per team over runs.
+type simulationTally struct {
+	ranks  [][]int64
+	points []int64
+}
+
+func newSimulationTally(n int) simulationTally {
+	t := simulationTally{ranks: make([][]int64, n), points: make([]int64, n)}
+	for i := range t.ranks {
+		t.ranks[i] = make([]int64, n)
+	}
+	return t
+}
+
+func (t simulationTally) merge(other simulationTally) {
+	for i := range t.ranks {
+		t.points[i] += other.points[i]
+		for j := range t.ranks[i] {
+			t.ranks[i][j] += other.ranks[i][j]
+		}
+	}
+}
+
+// chunkSeed derives the seed of a chunk of runs by hashing the simulator seed
+// and then the chunk number with splitmix64, so that the chunks of different
+// seeds do not share random streams.
+func chunkSeed(seed int64, chunk int) int64 {
+	return int64(splitmix64(splitmix64(uint64(seed)) ^ uint64(chunk)))
+}
+
+// splitmix64 returns the output of the splitmix64 generator in state x.
+func splitmix64(x uint64) uint64 {
+	z := x + 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// poissonSample draws from a Poisson distribution with the given mean.
+func poissonSample(rng *rand.Rand, mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+	limit := math.Exp(-mean)
+	k := 0
+	for p := rng.Float64(); p > limit; p *= rng.Float64() {
+		k++
+	}
+	return k
+}
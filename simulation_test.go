@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func simulationSeason() ([]Standings, []GeneralFixtureData) {
+	standings := []Standings{
+		{TeamID: 1, Team: "T1", Points: 30},
+		{TeamID: 2, Team: "T2", Points: 28},
+		{TeamID: 3, Team: "T3", Points: 20},
+		{TeamID: 4, Team: "T4", Points: 3},
+	}
+	var fixtures []GeneralFixtureData
+	for home := 1; home <= 4; home++ {
+		for away := 1; away <= 4; away++ {
+			if home != away {
+				fixtures = append(fixtures, playedFixture(20, home*4+away, home, away, -1, -1))
+			}
+		}
+	}
+	return standings, fixtures
+}
+
+func TestSimulatorDeterministicAcrossWorkers(t *testing.T) {
+	standings, fixtures := simulationSeason()
+	s := NewSimulator(nil)
+	s.Seed, s.Runs, s.Workers = 42, 3000, 1
+	single, err := s.Run(context.Background(), standings, fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Workers = 8
+	parallel, err := s.Run(context.Background(), standings, fixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(single, parallel) {
+		t.Fatalf("results differ with 1 and 8 workers")
+	}
+
+	for _, team := range single.Teams {
+		var sum float64
+		for _, p := range team.Ranks {
+			sum += p
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%s rank probabilities sum to %v", team.Team, sum)
+		}
+		// T4 cannot catch up with 6 matches left.
+		if team.TeamID == 4 && team.Title != 0 {
+			t.Errorf("T4 title probability = %v, want 0", team.Title)
+		}
+	}
+}
+
+func TestChunkSeedsDoNotOverlapAcrossSeeds(t *testing.T) {
+	seen := make(map[int64]bool)
+	for seed := int64(0); seed < 100; seed++ {
+		for chunk := 0; chunk < 100; chunk++ {
+			s := chunkSeed(seed, chunk)
+			if seen[s] {
+				t.Fatalf("chunk %d of seed %d reuses a seed", chunk, seed)
+			}
+			seen[s] = true
+		}
+	}
+
+	// Neighbouring seeds draw unrelated first numbers for the same chunk.
+	a := rand.New(rand.NewSource(chunkSeed(1, 0))).Int63()
+	b := rand.New(rand.NewSource(chunkSeed(2, 0))).Int63()
+	if a == b {
+		t.Fatalf("seeds 1 and 2 share their first draw")
+	}
+}
+
+func TestSplitmix64(t *testing.T) {
+	// First outputs of the reference generator seeded with 0.
+	state := uint64(0)
+	for _, want := range []uint64{0xe220a8397b1dcdaf, 0x6e789e6aa1b965f4, 0x06c45d188009454f} {
+		if got := splitmix64(state); got != want {
+			t.Errorf("splitmix64(%#x) = %#x, want %#x", state, got, want)
+		}
+		state += 0x9e3779b97f4a7c15
+	}
+}
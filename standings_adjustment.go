@@ -0,0 +1,90 @@
+package client
+
+import "time"
+
+// Adjustment represents a change to a team's points outside its match
+// results, such as a deduction for a financial breach. Points is negative for
+// deductions.
+type Adjustment struct {
+	TeamID        int       `json:"team_id" bson:"team_id"`
+	Team          string    `json:"team" bson:"team"`
+	Points        int       `json:"points" bson:"points"`
+	Reason        string    `json:"reason" bson:"reason"`
+	EffectiveDate time.Time `json:"effective_date" bson:"effective_date"`
+}
+
+// ResultOverride represents the official result of a fixture that replaces
+// its scoreline, such as a match awarded 3-0 by the league.
+type ResultOverride struct {
+	FixtureID     string    `json:"fixture_id" bson:"fixture_id"`
+	GoalsHome     int       `json:"goals_home" bson:"goals_home"`
+	GoalsAway     int       `json:"goals_away" bson:"goals_away"`
+	Reason        string    `json:"reason" bson:"reason"`
+	EffectiveDate time.Time `json:"effective_date" bson:"effective_date"`
+}
+
+// ApplyOverrides returns a copy of fixtures in which every fixture with an
+// override carries the overridden scoreline and the StatusAwarded status. Its
+// Winner is cleared so that the scoreline is taken as given.
+func ApplyOverrides(fixtures []GeneralFixtureData, overrides []ResultOverride) []GeneralFixtureData {
+	byID := make(map[string]ResultOverride, len(overrides))
+	for _, o := range overrides {
+		byID[o.FixtureID] = o
+	}
+	out := append([]GeneralFixtureData(nil), fixtures...)
+	for i, g := range out {
+		if o, ok := byID[g.FixtureID]; ok {
+			out[i].FixtureData.GoalsHome = o.GoalsHome
+			out[i].FixtureData.GoalsAway = o.GoalsAway
+			out[i].FixtureData.GameStatus = StatusAwarded
+			out[i].FixtureData.Winner = ""
+		}
+	}
+	return out
+}
+
+// effective reports whether a change dated at takes effect in a table as of
+// the options' At and after their Round, that is before the next round kicks
+// off. Undated changes always do.
+func (o StandingsOptions) effective(at time.Time) bool {
+	if at.IsZero() {
+		return true
+	}
+	if !o.At.IsZero() && !at.Before(o.At) {
+		return false
+	}
+	return o.nextRound.IsZero() || at.Before(o.nextRound)
+}
+
+// windowed reports whether the options select a window of the season, to
+// which points adjustments do not apply.
+func (o StandingsOptions) windowed() bool {
+	return !o.Since.IsZero() || o.FromRound > 0 || o.Last > 0 || o.Venue != VenueAll
+}
+
+// overrides returns the result overrides in effect.
+func (o StandingsOptions) overrides() []ResultOverride {
+	var out []ResultOverride
+	for _, r := range o.Overrides {
+		if o.effective(r.EffectiveDate) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// adjust applies the points adjustments in effect to the table.
+func (o StandingsOptions) adjust(table *standingsTable) {
+	if o.windowed() {
+		return
+	}
+	for _, a := range o.Adjustments {
+		if !o.effective(a.EffectiveDate) {
+			continue
+		}
+		row := table.team(a.TeamID, a.Team, "")
+		row.Points += a.Points
+		row.PointsAdjustment += a.Points
+		row.Adjustments = append(row.Adjustments, a)
+	}
+}
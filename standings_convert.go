@@ -0,0 +1,151 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConversionReport lists the fields that could not be carried over when
+// converting between the Standings and TeamStanding representations.
+type ConversionReport struct {
+	Dropped []DroppedField `json:"dropped"`
+}
+
+// DroppedField is a non-empty value lost in a conversion.
+type DroppedField struct {
+	Team  string `json:"team"`
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// Lossless reports whether the conversion kept every non-empty value.
+func (r ConversionReport) Lossless() bool {
+	return len(r.Dropped) == 0
+}
+
+func (r *ConversionReport) drop(team, field, value string) {
+	if value == "" || value == "0" {
+		return
+	}
+	r.Dropped = append(r.Dropped, DroppedField{Team: team, Field: field, Value: value})
+}
+
+// ToTeamStandings converts League standings into TeamStanding entries. The
+// Group, Status and Description that Standings cannot hold are taken from the
+// entry with the same team name in known, if any. TeamID, TeamLogo, the
+// points adjustment and awarded fixtures have no counterpart in TeamStanding
+// and are reported as dropped; Points keeps the adjustment.
+func ToTeamStandings(standings []Standings, known []TeamStanding) ([]TeamStanding, ConversionReport) {
+	byName := make(map[string]TeamStanding, len(known))
+	for _, k := range known {
+		byName[k.TeamName] = k
+	}
+
+	var report ConversionReport
+	out := make([]TeamStanding, 0, len(standings))
+	for _, s := range standings {
+		ts := TeamStanding{
+			Rank:      s.Rank,
+			TeamName:  s.Team,
+			Points:    s.Points,
+			GoalsDiff: s.GoalsDiff,
+			Form:      s.Form,
+			All:       playedData(s.Played, s.Wins, s.Draws, s.Losses, s.GoalsFor, s.GoalsAgainst),
+			Home:      playedData(s.HomePlayed, s.HomeWins, s.HomeDraws, s.HomeLosses, s.HomeGoalsFor, s.HomeGoalsAgainst),
+			Away:      playedData(s.AwayPlayed, s.AwayWins, s.AwayDraws, s.AwayLosses, s.AwayGoalsFor, s.AwayGoalsAgainst),
+		}
+		if k, ok := byName[s.Team]; ok {
+			ts.Group, ts.Status, ts.Description = k.Group, k.Status, k.Description
+		}
+		report.drop(s.Team, "team_id", strconv.Itoa(s.TeamID))
+		report.drop(s.Team, "team_logo", s.TeamLogo)
+		report.drop(s.Team, "points_adjustment", strconv.Itoa(s.PointsAdjustment))
+		report.drop(s.Team, "awarded_fixtures", strings.Join(s.AwardedFixtures, ","))
+		out = append(out, ts)
+	}
+	return out, report
+}
+
+// FromTeamStandings converts TeamStanding entries into League standings. The
+// TeamID and TeamLogo that TeamStanding cannot hold are taken from the entry
+// with the same team name in known, if any. Group, Status and Description have
+// no counterpart in Standings and are reported as dropped.
+func FromTeamStandings(standings []TeamStanding, known []Standings) ([]Standings, ConversionReport) {
+	byName := make(map[string]Standings, len(known))
+	for _, k := range known {
+		byName[k.Team] = k
+	}
+
+	var report ConversionReport
+	out := make([]Standings, 0, len(standings))
+	for _, ts := range standings {
+		s := Standings{
+			Rank:             ts.Rank,
+			Team:             ts.TeamName,
+			Points:           ts.Points,
+			GoalsDiff:        ts.GoalsDiff,
+			Form:             ts.Form,
+			Played:           ts.All.Played,
+			Wins:             ts.All.Win,
+			Draws:            ts.All.Draw,
+			Losses:           ts.All.Lose,
+			GoalsFor:         ts.All.Goals.For,
+			GoalsAgainst:     ts.All.Goals.Against,
+			HomePlayed:       ts.Home.Played,
+			HomeWins:         ts.Home.Win,
+			HomeDraws:        ts.Home.Draw,
+			HomeLosses:       ts.Home.Lose,
+			HomeGoalsFor:     ts.Home.Goals.For,
+			HomeGoalsAgainst: ts.Home.Goals.Against,
+			AwayPlayed:       ts.Away.Played,
+			AwayWins:         ts.Away.Win,
+			AwayDraws:        ts.Away.Draw,
+			AwayLosses:       ts.Away.Lose,
+			AwayGoalsFor:     ts.Away.Goals.For,
+			AwayGoalsAgainst: ts.Away.Goals.Against,
+		}
+		if k, ok := byName[ts.TeamName]; ok {
+			s.TeamID, s.TeamLogo = k.TeamID, k.TeamLogo
+		}
+		report.drop(ts.TeamName, "group", ts.Group)
+		report.drop(ts.TeamName, "status", ts.Status)
+		report.drop(ts.TeamName, "description", ts.Description)
+		out = append(out, s)
+	}
+	return out, report
+}
+
+// StandingsData converts the league's standings into a StandingsData named after the league.
+func (l League) StandingsData(known []TeamStanding) (StandingsData, ConversionReport) {
+	standings, report := ToTeamStandings(l.Standings, known)
+	return StandingsData{LeagueName: l.Name, Standings: standings}, report
+}
+
+// LeagueStandings converts the standings into League standings. A
+// LeagueName different from the league's name is reported as dropped.
+func (d StandingsData) LeagueStandings(league League) ([]Standings, ConversionReport) {
+	standings, report := FromTeamStandings(d.Standings, league.Standings)
+	if d.LeagueName != league.Name {
+		report.drop("", "league_name", d.LeagueName)
+	}
+	return standings, report
+}
+
+func playedData(played, win, draw, lose, goalsFor, goalsAgainst int) PlayedData {
+	return PlayedData{
+		Played: played,
+		Win:    win,
+		Draw:   draw,
+		Lose:   lose,
+		Goals:  GoalsData{For: goalsFor, Against: goalsAgainst},
+	}
+}
+
+// String describes the dropped field.
+func (d DroppedField) String() string {
+	if d.Team == "" {
+		return fmt.Sprintf("%s=%q", d.Field, d.Value)
+	}
+	return fmt.Sprintf("%s: %s=%q", d.Team, d.Field, d.Value)
+}
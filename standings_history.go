@@ -0,0 +1,308 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StandingsOptions selects the fixtures a computed table is built from and
+// how its ties are broken.
+type StandingsOptions struct {
+	// At keeps only fixtures that kicked off before it, when non-zero.
+	At time.Time
+	// Round keeps only fixtures of rounds up to and including it, when positive.
+	Round int
+	// Since keeps only fixtures that kicked off at or after it, when non-zero.
+	Since time.Time
+	// FromRound keeps only fixtures of rounds from it onwards, when positive.
+	FromRound int
+	// Last keeps only each team's most recent Last matches, when positive.
+	// It applies after the other filters, Venue included.
+	Last int
+	// Venue keeps only the teams' home or away matches.
+	Venue Venue
+	// HeadToHead breaks ties on points with ResolveTie instead of overall
+	// goal difference and goals scored.
+	HeadToHead bool
+	// Adjustments change teams' points in season tables, from their
+	// EffectiveDate when At or Round is set. Windowed tables ignore them.
+	Adjustments []Adjustment
+	// Overrides replace fixture results, from their EffectiveDate when At or
+	// Round is set.
+	Overrides []ResultOverride
+
+	// nextRound is the first kickoff after Round, set by ComputeStandings;
+	// changes dated from it on are not yet in effect.
+	nextRound time.Time
+}
+
+// Venue restricts a table to home or away matches.
+type Venue string
+
+// Venues a table can be restricted to.
+const (
+	VenueAll  Venue = ""
+	VenueHome Venue = "home"
+	VenueAway Venue = "away"
+)
+
+// teamResult is one team's side of a played fixture.
+type teamResult struct {
+	fixtureID    string
+	home         bool
+	awarded      bool
+	goalsFor     int
+	goalsAgainst int
+}
+
+// RankHistory is the position of a team after each round of a season.
+type RankHistory struct {
+	TeamID int         `json:"team_id" bson:"team_id"`
+	Team   string      `json:"team" bson:"team"`
+	Ranks  []RankPoint `json:"ranks" bson:"ranks"`
+}
+
+// RankPoint is a team's position and points after a round.
+type RankPoint struct {
+	Round  int `json:"round" bson:"round"`
+	Rank   int `json:"rank" bson:"rank"`
+	Points int `json:"points" bson:"points"`
+}
+
+// RoundNum returns the number of the fixture's round, parsed from the end of
+// LeagueRound as in "Regular Season - 12". It reports false when LeagueRound
+// does not end in a number.
+func (f FixtureData) RoundNum() (int, bool) {
+	round := strings.TrimSpace(f.LeagueRound)
+	i := strings.LastIndexFunc(round, func(r rune) bool { return r < '0' || r > '9' })
+	n, err := strconv.Atoi(round[i+1:])
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// ComputeStandings builds the table from the played fixtures selected by
+// opts. Every team appearing in fixtures has a row, so tables early in the
+// season list teams that have not played yet.
+func ComputeStandings(fixtures []GeneralFixtureData, opts StandingsOptions) []Standings {
+	latest := LatestFixtures(fixtures)
+	opts.nextRound = opts.nextRoundKickoff(latest)
+	sorted := ApplyOverrides(latest, opts.overrides())
+	sortFixtures(sorted)
+
+	table := newStandingsTable()
+	results := make(map[int][]teamResult)
+	for _, g := range sorted {
+		f := g.FixtureData
+		table.team(f.HomeTeamID, f.HomeTeam, f.HomeTeamLogo)
+		table.team(f.AwayTeamID, f.AwayTeam, f.AwayTeamLogo)
+		if !opts.includes(f) {
+			continue
+		}
+		home, away, ok := f.Result()
+		if !ok {
+			continue
+		}
+		awarded := f.Outcome() == OutcomeAwarded
+		if opts.Venue != VenueAway {
+			results[f.HomeTeamID] = append(results[f.HomeTeamID], teamResult{fixtureID: g.FixtureID, home: true, awarded: awarded, goalsFor: home, goalsAgainst: away})
+		}
+		if opts.Venue != VenueHome {
+			results[f.AwayTeamID] = append(results[f.AwayTeamID], teamResult{fixtureID: g.FixtureID, awarded: awarded, goalsFor: away, goalsAgainst: home})
+		}
+	}
+	for id, rs := range results {
+		if opts.Last > 0 && len(rs) > opts.Last {
+			rs = rs[len(rs)-opts.Last:]
+		}
+		row := table.rows[id]
+		for _, r := range rs {
+			if r.home {
+				row.addHome(r.goalsFor, r.goalsAgainst)
+			} else {
+				row.addAway(r.goalsFor, r.goalsAgainst)
+			}
+			if r.awarded {
+				row.AwardedFixtures = append(row.AwardedFixtures, r.fixtureID)
+			}
+		}
+	}
+	opts.adjust(table)
+
+	standings := table.standings()
+	if opts.HeadToHead {
+		return RankStandings(standings, opts.played(sorted))
+	}
+	return standings
+}
+
+// StandingsAt returns the table as it stood at the given time.
+func StandingsAt(fixtures []GeneralFixtureData, at time.Time) []Standings {
+	return ComputeStandings(fixtures, StandingsOptions{At: at})
+}
+
+// StandingsAfterRound returns the table after the given round.
+func StandingsAfterRound(fixtures []GeneralFixtureData, round int) []Standings {
+	return ComputeStandings(fixtures, StandingsOptions{Round: round})
+}
+
+// RankHistories returns each team's position after every round found in
+// fixtures, computed with opts other than Round. Fixtures whose round has no
+// number count towards every round but add no point to the series.
+func RankHistories(fixtures []GeneralFixtureData, opts StandingsOptions) []RankHistory {
+	seen := make(map[int]bool)
+	var rounds []int
+	for _, g := range fixtures {
+		if n, ok := g.FixtureData.RoundNum(); ok && !seen[n] {
+			seen[n] = true
+			rounds = append(rounds, n)
+		}
+	}
+	sort.Ints(rounds)
+
+	return rankHistories(rounds, func(round int) []Standings {
+		opts.Round = round
+		return ComputeStandings(fixtures, opts)
+	})
+}
+
+// ComputeStandings builds the table from the league's TeamsPath with the
+// same options as ComputeStandings. A round fixture counts once the team has
+// a result for it.
+func (l League) ComputeStandings(opts StandingsOptions) []Standings {
+	return ComputeStandings(l.pathFixtures(), opts)
+}
+
+// StandingsAfterRound returns the table after the given round computed from
+// the league's TeamsPath.
+func (l League) StandingsAfterRound(round int) []Standings {
+	return l.ComputeStandings(StandingsOptions{Round: round})
+}
+
+// RankHistories returns each team's position after every round of the
+// league's TeamsPath, computed with opts other than Round.
+func (l League) RankHistories(opts StandingsOptions) []RankHistory {
+	return RankHistories(l.pathFixtures(), opts)
+}
+
+// pathFixtures rebuilds the fixtures of the league's TeamsPath, once per
+// fixture however many of its teams list it. Fixtures with a result are
+// finished; the others are not started.
+func (l League) pathFixtures() []GeneralFixtureData {
+	teams := make(map[string]TeamPath, len(l.TeamsPath))
+	for _, path := range l.TeamsPath {
+		teams[path.TeamID] = path
+	}
+
+	var fixtures []GeneralFixtureData
+	seen := make(map[string]bool)
+	for _, path := range l.TeamsPath {
+		for _, rf := range path.RoundFixtures {
+			home, away := path, teams[rf.AgainstTeamID]
+			goalsHome, goalsAway := rf.Goals, rf.GoalsAgainst
+			if away.TeamID == "" {
+				away = TeamPath{TeamID: rf.AgainstTeamID, TeamName: rf.AgainstTeam}
+			}
+			if !rf.HomeGame {
+				home, away = away, home
+				goalsHome, goalsAway = goalsAway, goalsHome
+			}
+			key := rf.FixtureID
+			if key == "" {
+				key = fmt.Sprintf("%s-%s-%s-%d", rf.Round, home.TeamID, away.TeamID, rf.RoundNum)
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			f := FixtureData{
+				LeagueRound:  rf.Round,
+				Date:         rf.Date,
+				HomeTeam:     home.TeamName,
+				HomeTeamLogo: home.TeamLogo,
+				AwayTeam:     away.TeamName,
+				AwayTeamLogo: away.TeamLogo,
+				GameStatus:   StatusNotStarted,
+			}
+			f.HomeTeamID, _ = strconv.Atoi(home.TeamID)
+			f.AwayTeamID, _ = strconv.Atoi(away.TeamID)
+			if n, ok := f.RoundNum(); (!ok || n != rf.RoundNum) && rf.RoundNum > 0 {
+				f.LeagueRound = strconv.Itoa(rf.RoundNum)
+			}
+			if rf.ResultForTeam != "" {
+				f.GameStatus, f.GoalsHome, f.GoalsAway = StatusFinished, goalsHome, goalsAway
+			}
+			fixtures = append(fixtures, GeneralFixtureData{FixtureID: rf.FixtureID, LeagueID: l.ID, FixtureData: f})
+		}
+	}
+	return fixtures
+}
+
+func rankHistories(rounds []int, table func(round int) []Standings) []RankHistory {
+	var histories []RankHistory
+	index := make(map[int]int)
+	for _, round := range rounds {
+		for _, s := range table(round) {
+			i, ok := index[s.TeamID]
+			if !ok {
+				i = len(histories)
+				index[s.TeamID] = i
+				histories = append(histories, RankHistory{TeamID: s.TeamID, Team: s.Team})
+			}
+			histories[i].Ranks = append(histories[i].Ranks, RankPoint{Round: round, Rank: s.Rank, Points: s.Points})
+		}
+	}
+	return histories
+}
+
+// includes reports whether the fixture falls within the options' dates and rounds.
+func (o StandingsOptions) includes(f FixtureData) bool {
+	if !o.At.IsZero() && !f.Date.Before(o.At) {
+		return false
+	}
+	if !o.Since.IsZero() && f.Date.Before(o.Since) {
+		return false
+	}
+	if n, ok := f.RoundNum(); ok {
+		if (o.Round > 0 && n > o.Round) || (o.FromRound > 0 && n < o.FromRound) {
+			return false
+		}
+	}
+	return true
+}
+
+// nextRoundKickoff returns the earliest kickoff of the rounds after Round, or
+// the zero time when Round is not set or no later round has a date.
+func (o StandingsOptions) nextRoundKickoff(fixtures []GeneralFixtureData) time.Time {
+	var next time.Time
+	if o.Round <= 0 {
+		return next
+	}
+	for _, g := range fixtures {
+		f := g.FixtureData
+		n, ok := f.RoundNum()
+		if !ok || n <= o.Round || f.Date.IsZero() {
+			continue
+		}
+		if next.IsZero() || f.Date.Before(next) {
+			next = f.Date
+		}
+	}
+	return next
+}
+
+// played returns the fixtures the options include, for head-to-head tiebreaks.
+func (o StandingsOptions) played(fixtures []GeneralFixtureData) []GeneralFixtureData {
+	var out []GeneralFixtureData
+	for _, g := range fixtures {
+		if o.includes(g.FixtureData) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// playedFixture returns a fixture of the given round between teams home and
+// away kicking off on the given day of January 2024. Negative goals leave it
+// not started.
+func playedFixture(round, day, home, away, goalsHome, goalsAway int) GeneralFixtureData {
+	status := StatusFinished
+	if goalsHome < 0 {
+		status, goalsHome, goalsAway = StatusNotStarted, 0, 0
+	}
+	return GeneralFixtureData{
+		FixtureID: fmt.Sprintf("r%d-%d-%d", round, home, away),
+		FixtureData: FixtureData{
+			LeagueRound: fmt.Sprintf("Regular Season - %d", round),
+			Date:        time.Date(2024, 1, day, 15, 0, 0, 0, time.UTC),
+			HomeTeamID:  home,
+			HomeTeam:    fmt.Sprintf("T%d", home),
+			AwayTeamID:  away,
+			AwayTeam:    fmt.Sprintf("T%d", away),
+			GoalsHome:   goalsHome,
+			GoalsAway:   goalsAway,
+			GameStatus:  status,
+		},
+	}
+}
+
+func january(day int) time.Time {
+	return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+}
+
+func standingsPoints(standings []Standings) map[int]int {
+	points := make(map[int]int, len(standings))
+	for _, s := range standings {
+		points[s.TeamID] = s.Points
+	}
+	return points
+}
+
+func adjustedSeason() ([]GeneralFixtureData, StandingsOptions) {
+	fixtures := []GeneralFixtureData{
+		playedFixture(1, 1, 1, 2, 2, 0),
+		playedFixture(1, 1, 3, 4, 0, 0),
+		playedFixture(2, 8, 2, 3, 3, 0),
+		playedFixture(2, 8, 4, 1, 1, 0),
+		playedFixture(3, 15, 1, 3, -1, -1),
+	}
+	opts := StandingsOptions{
+		Adjustments: []Adjustment{
+			{TeamID: 1, Points: -6, EffectiveDate: january(5)},
+			{TeamID: 3, Points: -2, EffectiveDate: january(9)},
+			{TeamID: 4, Points: -1, EffectiveDate: january(20)},
+		},
+		Overrides: []ResultOverride{{FixtureID: "r2-4-1", GoalsHome: 0, GoalsAway: 3, EffectiveDate: january(10)}},
+	}
+	return fixtures, opts
+}
+
+func TestComputeStandingsAdjustmentsByRound(t *testing.T) {
+	fixtures, opts := adjustedSeason()
+	tests := []struct {
+		round int
+		want  map[int]int
+	}{
+		// Only the deduction dated before round 2 applies after round 1.
+		{1, map[int]int{1: -3, 2: 0, 3: 1, 4: 1}},
+		// The override and the second deduction precede round 3.
+		{2, map[int]int{1: 0, 2: 3, 3: -1, 4: 1}},
+		// No round follows the last one, so every change applies.
+		{3, map[int]int{1: 0, 2: 3, 3: -1, 4: 0}},
+	}
+	for _, tt := range tests {
+		opts.Round = tt.round
+		if got := standingsPoints(ComputeStandings(fixtures, opts)); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("after round %d points = %v, want %v", tt.round, got, tt.want)
+		}
+	}
+
+	opts.Round = 0
+	opts.At = january(9)
+	if got, want := standingsPoints(ComputeStandings(fixtures, opts)), map[int]int{1: -3, 2: 3, 3: 1, 4: 4}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("at January 9 points = %v, want %v", got, want)
+	}
+}
+
+func TestRankHistoriesApplyAdjustmentsByRound(t *testing.T) {
+	fixtures, opts := adjustedSeason()
+	histories := RankHistories(fixtures, opts)
+	want := map[int][]RankPoint{
+		1: {{Round: 1, Rank: 4, Points: -3}, {Round: 2, Rank: 3, Points: 0}, {Round: 3, Rank: 2, Points: 0}},
+		2: {{Round: 1, Rank: 3, Points: 0}, {Round: 2, Rank: 1, Points: 3}, {Round: 3, Rank: 1, Points: 3}},
+	}
+	for _, h := range histories {
+		if w, ok := want[h.TeamID]; ok && fmt.Sprint(h.Ranks) != fmt.Sprint(w) {
+			t.Errorf("team %d ranks = %v, want %v", h.TeamID, h.Ranks, w)
+		}
+	}
+}
+
+func TestRoundNum(t *testing.T) {
+	tests := []struct {
+		round string
+		want  int
+		ok    bool
+	}{
+		{"Regular Season - 12", 12, true},
+		{"3", 3, true},
+		{"Final", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := (FixtureData{LeagueRound: tt.round}).RoundNum(); got != tt.want || ok != tt.ok {
+			t.Errorf("RoundNum(%q) = %d, %v, want %d, %v", tt.round, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLeagueStandingsMatchFixtures(t *testing.T) {
+	fixtures, opts := adjustedSeason()
+	league := League{ID: "39", TeamsPath: BuildTeamsPath(fixtures)}
+	for round := 0; round <= 3; round++ {
+		opts.Round = round
+		got, want := league.ComputeStandings(opts), ComputeStandings(fixtures, opts)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("round %d from TeamsPath = %+v, want %+v", round, got, want)
+		}
+	}
+	opts.Round = 0
+	if got, want := league.RankHistories(opts), RankHistories(fixtures, opts); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("rank histories from TeamsPath = %+v, want %+v", got, want)
+	}
+}
+
+func TestLeagueStandingsAfterRoundWithoutFixtureIDs(t *testing.T) {
+	league := League{TeamsPath: []TeamPath{
+		{TeamID: "1", TeamName: "X", TeamLogo: "x.png", RoundFixtures: []RoundFixture{
+			{RoundNum: 1, AgainstTeamID: "2", HomeGame: true, ResultForTeam: "W", Goals: 2},
+			{RoundNum: 2, AgainstTeamID: "2", ResultForTeam: "L", GoalsAgainst: 1},
+		}},
+		{TeamID: "2", TeamName: "Y", RoundFixtures: []RoundFixture{
+			{RoundNum: 1, AgainstTeamID: "1", ResultForTeam: "L", GoalsAgainst: 2},
+			{RoundNum: 2, AgainstTeamID: "1", HomeGame: true, ResultForTeam: "W", Goals: 1},
+		}},
+	}}
+	after1 := league.StandingsAfterRound(1)
+	if len(after1) != 2 || after1[0].Team != "X" || after1[0].Points != 3 || after1[0].Played != 1 || after1[0].TeamLogo != "x.png" {
+		t.Fatalf("after round 1 = %+v", after1)
+	}
+	after2 := league.StandingsAfterRound(2)
+	if after2[0].Team != "X" || after2[0].Points != 3 || after2[0].Played != 2 || after2[1].Points != 3 {
+		t.Fatalf("after round 2 = %+v", after2)
+	}
+}
@@ -0,0 +1,165 @@
+package client
+
+import "sort"
+
+// Points awarded per result in league standings.
+const (
+	pointsWin  = 3
+	pointsDraw = 1
+)
+
+// formLength is the number of recent results kept in Standings.Form.
+const formLength = 5
+
+// standingsTable accumulates Standings rows from fixture results.
+type standingsTable struct {
+	rows  map[int]*Standings
+	order []int
+}
+
+func newStandingsTable() *standingsTable {
+	return &standingsTable{rows: make(map[int]*Standings)}
+}
+
+// team returns the row of the given team, creating it when first seen.
+func (t *standingsTable) team(id int, name, logo string) *Standings {
+	row, ok := t.rows[id]
+	if !ok {
+		row = &Standings{TeamID: id, Team: name, TeamLogo: logo}
+		t.rows[id] = row
+		t.order = append(t.order, id)
+	}
+	if row.Team == "" {
+		row.Team = name
+	}
+	if row.TeamLogo == "" {
+		row.TeamLogo = logo
+	}
+	return row
+}
+
+// add records a fixture with the given final score for both teams.
+func (t *standingsTable) add(f FixtureData, goalsHome, goalsAway int) {
+	home := t.team(f.HomeTeamID, f.HomeTeam, f.HomeTeamLogo)
+	away := t.team(f.AwayTeamID, f.AwayTeam, f.AwayTeamLogo)
+	home.addHome(goalsHome, goalsAway)
+	away.addAway(goalsAway, goalsHome)
+}
+
+// standings returns the accumulated rows sorted and ranked.
+func (t *standingsTable) standings() []Standings {
+	out := make([]Standings, 0, len(t.order))
+	for _, id := range t.order {
+		out = append(out, *t.rows[id])
+	}
+	sortStandings(out)
+	rankStandings(out)
+	return out
+}
+
+func (s *Standings) addHome(goalsFor, goalsAgainst int) {
+	s.HomePlayed++
+	s.HomeGoalsFor += goalsFor
+	s.HomeGoalsAgainst += goalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		s.HomeWins++
+	case goalsFor < goalsAgainst:
+		s.HomeLosses++
+	default:
+		s.HomeDraws++
+	}
+	s.addResult(goalsFor, goalsAgainst)
+}
+
+func (s *Standings) addAway(goalsFor, goalsAgainst int) {
+	s.AwayPlayed++
+	s.AwayGoalsFor += goalsFor
+	s.AwayGoalsAgainst += goalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		s.AwayWins++
+	case goalsFor < goalsAgainst:
+		s.AwayLosses++
+	default:
+		s.AwayDraws++
+	}
+	s.addResult(goalsFor, goalsAgainst)
+}
+
+func (s *Standings) addResult(goalsFor, goalsAgainst int) {
+	s.Played++
+	s.GoalsFor += goalsFor
+	s.GoalsAgainst += goalsAgainst
+	s.GoalsDiff = s.GoalsFor - s.GoalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		s.Wins++
+		s.Points += pointsWin
+		s.addForm('W')
+	case goalsFor < goalsAgainst:
+		s.Losses++
+		s.addForm('L')
+	default:
+		s.Draws++
+		s.Points += pointsDraw
+		s.addForm('D')
+	}
+}
+
+// addForm appends a result to Form, oldest first, keeping the last formLength.
+func (s *Standings) addForm(result byte) {
+	s.Form += string(result)
+	if len(s.Form) > formLength {
+		s.Form = s.Form[len(s.Form)-formLength:]
+	}
+}
+
+// sortStandings orders rows by points, goal difference, goals scored and team name.
+func sortStandings(rows []Standings) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareStandings(rows[i], rows[j]) < 0
+	})
+}
+
+// compareStandings returns -1 when a ranks above b on points, goal difference
+// and goals scored, 1 when below, and falls back to the team name.
+func compareStandings(a, b Standings) int {
+	if c := compareRecord(a, b); c != 0 {
+		return c
+	}
+	switch {
+	case a.Team < b.Team:
+		return -1
+	case a.Team > b.Team:
+		return 1
+	}
+	return 0
+}
+
+// compareRecord compares points, goal difference and goals scored only.
+func compareRecord(a, b Standings) int {
+	switch {
+	case a.Points != b.Points:
+		return descending(a.Points, b.Points)
+	case a.GoalsDiff != b.GoalsDiff:
+		return descending(a.GoalsDiff, b.GoalsDiff)
+	case a.GoalsFor != b.GoalsFor:
+		return descending(a.GoalsFor, b.GoalsFor)
+	}
+	return 0
+}
+
+func descending(a, b int) int {
+	if a > b {
+		return -1
+	}
+	return 1
+}
+
+// rankStandings numbers rows from 1 in their current order.
+func rankStandings(rows []Standings) {
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import "time"
+
+// FormTable returns the table of each team's last matches at the given venue.
+func FormTable(fixtures []GeneralFixtureData, last int, venue Venue) []Standings {
+	return ComputeStandings(fixtures, StandingsOptions{Last: last, Venue: venue})
+}
+
+// TableSince returns the table of the matches played at the given venue since a date.
+func TableSince(fixtures []GeneralFixtureData, since time.Time, venue Venue) []Standings {
+	return ComputeStandings(fixtures, StandingsOptions{Since: since, Venue: venue})
+}
+
+// SecondHalfTable returns the table of the matches played at the given venue
+// in the second half of the season, from the round after the midpoint of the
+// highest round found in fixtures.
+func SecondHalfTable(fixtures []GeneralFixtureData, venue Venue) []Standings {
+	return ComputeStandings(fixtures, StandingsOptions{FromRound: SecondHalfRound(fixtures), Venue: venue})
+}
+
+// SecondHalfRound returns the first round of the second half of the season,
+// as 20 for a 38-round league. It returns 0 when no fixture has a round number.
+func SecondHalfRound(fixtures []GeneralFixtureData) int {
+	last := 0
+	for _, g := range fixtures {
+		if n, ok := g.FixtureData.RoundNum(); ok && n > last {
+			last = n
+		}
+	}
+	if last == 0 {
+		return 0
+	}
+	return last/2 + 1
+}
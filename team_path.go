@@ -0,0 +1,70 @@
+package client
+
+import "strconv"
+
+// BuildTeamsPath builds each team's path through the league from fixtures,
+// in kickoff order. Cancelled fixtures are left out. Fixtures without a result,
+// such as postponed or abandoned ones, are listed with an empty ResultForTeam
+// and no points or goals; awarded fixtures carry their official scoreline.
+func BuildTeamsPath(fixtures []GeneralFixtureData) []TeamPath {
+	sorted := LatestFixtures(fixtures)
+	sortFixtures(sorted)
+
+	var paths []TeamPath
+	index := make(map[int]int)
+	path := func(id int, name, logo string) *TeamPath {
+		i, ok := index[id]
+		if !ok {
+			i = len(paths)
+			index[id] = i
+			paths = append(paths, TeamPath{TeamID: strconv.Itoa(id), TeamName: name, TeamLogo: logo})
+		}
+		return &paths[i]
+	}
+
+	for _, g := range sorted {
+		f := g.FixtureData
+		if f.Outcome() == OutcomeCancelled {
+			continue
+		}
+		home, away, ok := f.Result()
+		path(f.HomeTeamID, f.HomeTeam, f.HomeTeamLogo).add(g, true, home, away, ok)
+		path(f.AwayTeamID, f.AwayTeam, f.AwayTeamLogo).add(g, false, away, home, ok)
+	}
+	return paths
+}
+
+// add appends a fixture to the path from the team's side.
+func (p *TeamPath) add(g GeneralFixtureData, homeGame bool, goals, goalsAgainst int, played bool) {
+	f := g.FixtureData
+	rf := RoundFixture{
+		Round:         f.LeagueRound,
+		FixtureID:     g.FixtureID,
+		Date:          f.Date,
+		HomeGame:      homeGame,
+		AgainstTeam:   f.AwayTeam,
+		AgainstTeamID: strconv.Itoa(f.AwayTeamID),
+	}
+	if !homeGame {
+		rf.AgainstTeam, rf.AgainstTeamID = f.HomeTeam, strconv.Itoa(f.HomeTeamID)
+	}
+	rf.RoundNum, _ = f.RoundNum()
+	if n := len(p.RoundFixtures); n > 0 {
+		rf.TotalGoal = p.RoundFixtures[n-1].TotalGoal
+		rf.TotalGoalAgainst = p.RoundFixtures[n-1].TotalGoalAgainst
+	}
+	if played {
+		rf.Goals, rf.GoalsAgainst = goals, goalsAgainst
+		rf.TotalGoal += goals
+		rf.TotalGoalAgainst += goalsAgainst
+		switch {
+		case goals > goalsAgainst:
+			rf.ResultForTeam, rf.Points = "W", pointsWin
+		case goals < goalsAgainst:
+			rf.ResultForTeam = "L"
+		default:
+			rf.ResultForTeam, rf.Points = "D", pointsDraw
+		}
+	}
+	p.RoundFixtures = append(p.RoundFixtures, rf)
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ComputeTeamStatistics derives a team's result, goal, streak, clean sheet and
+// failed-to-score figures from fixtures. Only played fixtures count: awarded
+// fixtures were not completed on the pitch and postponed, abandoned and
+// cancelled ones have no result. Figures that need events or lineups are left empty.
+func ComputeTeamStatistics(fixtures []GeneralFixtureData, teamID int) TeamStatistics {
+	sorted := LatestFixtures(fixtures)
+	sortFixtures(sorted)
+
+	var (
+		s                    TeamStatistics
+		bestHome, bestAway   [2]int
+		worstHome, worstAway [2]int
+		streak               byte
+		streakLen            int
+	)
+	for _, g := range sorted {
+		f := g.FixtureData
+		if f.HomeTeamID != teamID && f.AwayTeamID != teamID {
+			continue
+		}
+		home := f.HomeTeamID == teamID
+		if home {
+			s.TeamName = f.HomeTeam
+		} else {
+			s.TeamName = f.AwayTeam
+		}
+		if !f.Outcome().CountsInStatistics() {
+			continue
+		}
+
+		goalsFor, goalsAgainst := f.GoalsHome, f.GoalsAway
+		if !home {
+			goalsFor, goalsAgainst = goalsAgainst, goalsFor
+		}
+		result := byte('D')
+		switch {
+		case goalsFor > goalsAgainst:
+			result = 'W'
+		case goalsFor < goalsAgainst:
+			result = 'L'
+		}
+		s.Form += string(result)
+		s.Total++
+		s.GoalsTotal += goalsFor
+		s.AgainstGoalTotal += goalsAgainst
+
+		if home {
+			s.PlayedHome++
+			s.GoalsHome += goalsFor
+			s.AgainstGoalHome += goalsAgainst
+			s.BiggestGoalsForHome = max(s.BiggestGoalsForHome, goalsFor)
+			s.BiggestGoalsAgainstHome = max(s.BiggestGoalsAgainstHome, goalsAgainst)
+			countResult(result, &s.WinsHome, &s.DrawsHome, &s.LosesHome)
+			countZero(goalsAgainst, &s.CleanSheetsHome)
+			countZero(goalsFor, &s.FailedToScoreHome)
+			biggestMargin(result, goalsFor, goalsAgainst, &bestHome, &worstHome)
+		} else {
+			s.PlayedAway++
+			s.GoalsAway += goalsFor
+			s.AgainstGoalAway += goalsAgainst
+			s.BiggestGoalsForAway = max(s.BiggestGoalsForAway, goalsFor)
+			s.BiggestGoalsAgainstAway = max(s.BiggestGoalsAgainstAway, goalsAgainst)
+			countResult(result, &s.WinsAway, &s.DrawsAway, &s.LosesAway)
+			countZero(goalsAgainst, &s.CleanSheetsAway)
+			countZero(goalsFor, &s.FailedToScoreAway)
+			biggestMargin(result, goalsFor, goalsAgainst, &bestAway, &worstAway)
+		}
+
+		if result == streak {
+			streakLen++
+		} else {
+			streak, streakLen = result, 1
+		}
+		switch result {
+		case 'W':
+			s.BiggestSteakWins = max(s.BiggestSteakWins, streakLen)
+		case 'D':
+			s.BiggestSteakDraws = max(s.BiggestSteakDraws, streakLen)
+		case 'L':
+			s.BiggestSteakLoses = max(s.BiggestSteakLoses, streakLen)
+		}
+	}
+
+	s.WinsTotal = s.WinsHome + s.WinsAway
+	s.DrawsTotal = s.DrawsHome + s.DrawsAway
+	s.LosesTotal = s.LosesHome + s.LosesAway
+	s.CleanSheetsTotal = s.CleanSheetsHome + s.CleanSheetsAway
+	s.FailedToScoreTotal = s.FailedToScoreHome + s.FailedToScoreAway
+	s.GoalAvgTotal = goalAverage(s.GoalsTotal, s.Total)
+	s.GoalAvgHome = goalAverage(s.GoalsHome, s.PlayedHome)
+	s.GoalAvgAway = goalAverage(s.GoalsAway, s.PlayedAway)
+	s.AgainstGoalAvgTotal = goalAverage(s.AgainstGoalTotal, s.Total)
+	s.AgainstGoalAvgHome = goalAverage(s.AgainstGoalHome, s.PlayedHome)
+	s.AgainstGoalAvgAway = goalAverage(s.AgainstGoalAway, s.PlayedAway)
+	s.BiggestWinsHome = scoreline(bestHome)
+	s.BiggestWinsAway = scoreline(bestAway)
+	s.BiggestLosesHome = scoreline(worstHome)
+	s.BiggestLosesAway = scoreline(worstAway)
+	return s
+}
+
+func countResult(result byte, wins, draws, loses *int) {
+	switch result {
+	case 'W':
+		*wins++
+	case 'D':
+		*draws++
+	case 'L':
+		*loses++
+	}
+}
+
+func countZero(goals int, n *int) {
+	if goals == 0 {
+		*n++
+	}
+}
+
+// biggestMargin keeps the widest win and defeat as [for, against] pairs.
+func biggestMargin(result byte, goalsFor, goalsAgainst int, best, worst *[2]int) {
+	switch result {
+	case 'W':
+		if goalsFor-goalsAgainst > best[0]-best[1] {
+			*best = [2]int{goalsFor, goalsAgainst}
+		}
+	case 'L':
+		if goalsAgainst-goalsFor > worst[1]-worst[0] {
+			*worst = [2]int{goalsFor, goalsAgainst}
+		}
+	}
+}
+
+// scoreline formats a [for, against] pair as "4-0", or "" when unset.
+func scoreline(score [2]int) string {
+	if score == [2]int{} {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", score[0], score[1])
+}
+
+// goalAverage formats goals per match with one decimal, as API-Football does.
+func goalAverage(goals, played int) string {
+	if played == 0 {
+		return "0.0"
+	}
+	return strconv.FormatFloat(float64(goals)/float64(played), 'f', 1, 64)
+}
@@ -0,0 +1,83 @@
+package client
+
+import "sort"
+
+// HeadToHeadTable computes the mini-league of the given teams from their
+// mutual played fixtures, ranked on points, goal difference and goals scored.
+// Every team in group has a row, even without a mutual fixture.
+func HeadToHeadTable(group []Standings, fixtures []GeneralFixtureData) []Standings {
+	table := newStandingsTable()
+	ids := make(map[int]bool, len(group))
+	for _, s := range group {
+		table.team(s.TeamID, s.Team, s.TeamLogo)
+		ids[s.TeamID] = true
+	}
+	for _, g := range fixtures {
+		f := g.FixtureData
+		if !ids[f.HomeTeamID] || !ids[f.AwayTeamID] {
+			continue
+		}
+		if home, away, ok := f.Result(); ok {
+			table.add(f, home, away)
+		}
+	}
+	return table.standings()
+}
+
+// ResolveTie orders a group of teams level on points by their head-to-head
+// mini-league: points, then goal difference, then goals scored in the mutual
+// fixtures. Teams the mini-league leaves level are resolved again by a
+// mini-league of only their own fixtures, until a pass separates no one; the
+// rest are ordered by overall goal difference, goals scored and team name.
+// The returned rows keep their overall figures and are not re-ranked.
+func ResolveTie(group []Standings, fixtures []GeneralFixtureData) []Standings {
+	out := append([]Standings(nil), group...)
+	resolveTie(out, fixtures)
+	return out
+}
+
+func resolveTie(group []Standings, fixtures []GeneralFixtureData) {
+	if len(group) < 2 {
+		return
+	}
+	mini := make(map[int]Standings, len(group))
+	for _, s := range HeadToHeadTable(group, fixtures) {
+		mini[s.TeamID] = s
+	}
+	sort.SliceStable(group, func(i, j int) bool {
+		if c := compareRecord(mini[group[i].TeamID], mini[group[j].TeamID]); c != 0 {
+			return c < 0
+		}
+		return compareStandings(group[i], group[j]) < 0
+	})
+
+	for start := 0; start < len(group); {
+		end := start + 1
+		for end < len(group) && compareRecord(mini[group[start].TeamID], mini[group[end].TeamID]) == 0 {
+			end++
+		}
+		if sub := group[start:end]; len(sub) > 1 && len(sub) < len(group) {
+			resolveTie(sub, fixtures)
+		}
+		start = end
+	}
+}
+
+// RankStandings orders standings by points, breaking ties with ResolveTie,
+// and renumbers their Rank.
+func RankStandings(standings []Standings, fixtures []GeneralFixtureData) []Standings {
+	out := append([]Standings(nil), standings...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Points > out[j].Points
+	})
+	for start := 0; start < len(out); {
+		end := start + 1
+		for end < len(out) && out[end].Points == out[start].Points {
+			end++
+		}
+		resolveTie(out[start:end], fixtures)
+		start = end
+	}
+	rankStandings(out)
+	return out
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+)
+
+func meeting(home, away, goalsHome, goalsAway int) GeneralFixtureData {
+	return GeneralFixtureData{
+		FixtureID: fmt.Sprintf("%d-%d", home, away),
+		FixtureData: FixtureData{
+			HomeTeamID: home,
+			HomeTeam:   fmt.Sprintf("T%d", home),
+			AwayTeamID: away,
+			AwayTeam:   fmt.Sprintf("T%d", away),
+			GoalsHome:  goalsHome,
+			GoalsAway:  goalsAway,
+			GameStatus: StatusFinished,
+		},
+	}
+}
+
+func tiedGroup() []Standings {
+	return []Standings{
+		{TeamID: 1, Team: "T1", Points: 10},
+		{TeamID: 2, Team: "T2", Points: 10},
+		{TeamID: 3, Team: "T3", Points: 10, GoalsDiff: 10},
+		{TeamID: 4, Team: "T4", Points: 10, GoalsDiff: 20},
+	}
+}
+
+func teamIDs(standings []Standings) string {
+	ids := make([]int, len(standings))
+	for i, s := range standings {
+		ids[i] = s.TeamID
+	}
+	return fmt.Sprint(ids)
+}
+
+func TestResolveTie(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixtures []GeneralFixtureData
+		want     string
+	}{
+		// No mutual fixture: overall goal difference, then team name.
+		{"overall", nil, "[4 3 1 2]"},
+		// T1 6, T2 and T3 4 with equal goals, T4 2 in the mini-league. T2 beat
+		// T3, which the second pass over their own meeting finds although T3
+		// has the better overall goal difference.
+		{"recursive", []GeneralFixtureData{
+			meeting(1, 2, 1, 0), meeting(3, 1, 1, 0), meeting(2, 3, 1, 0),
+			meeting(1, 4, 2, 0), meeting(4, 2, 0, 0), meeting(3, 4, 0, 0),
+		}, "[1 2 3 4]"},
+		// A cycle between T2, T3 and T4 with equal goals stays level in its
+		// own mini-league and falls back to overall goal difference.
+		{"cycle", []GeneralFixtureData{
+			meeting(1, 2, 1, 0), meeting(1, 3, 1, 0), meeting(1, 4, 1, 0),
+			meeting(2, 3, 1, 0), meeting(3, 4, 1, 0), meeting(4, 2, 1, 0),
+		}, "[1 4 3 2]"},
+		// T1 and T2 have 4 points in the mini-league; T1's goal difference of
+		// 3 beats T2's 1 although T2 scored 4 goals to T1's 3.
+		{"mini goal difference", []GeneralFixtureData{
+			meeting(1, 2, 0, 0), meeting(3, 4, 1, 0), meeting(4, 3, 4, 0),
+			meeting(1, 3, 3, 0), meeting(2, 4, 4, 3),
+		}, "[1 2 4 3]"},
+	}
+	for _, tt := range tests {
+		if got := teamIDs(ResolveTie(tiedGroup(), tt.fixtures)); got != tt.want {
+			t.Errorf("%s: order = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankStandingsOnlyBreaksTiesOnPoints(t *testing.T) {
+	standings := append(tiedGroup(), Standings{TeamID: 5, Team: "T5", Points: 12}, Standings{TeamID: 6, Team: "T6", Points: 1, GoalsDiff: 30})
+	fixtures := []GeneralFixtureData{
+		meeting(1, 2, 1, 0), meeting(3, 1, 1, 0), meeting(2, 3, 1, 0),
+		meeting(1, 4, 2, 0), meeting(4, 2, 0, 0), meeting(3, 4, 0, 0),
+		meeting(6, 5, 5, 0),
+	}
+	ranked := RankStandings(standings, fixtures)
+	if got := teamIDs(ranked); got != "[5 1 2 3 4 6]" {
+		t.Fatalf("order = %s, want [5 1 2 3 4 6]", got)
+	}
+	for i, s := range ranked {
+		if s.Rank != i+1 {
+			t.Errorf("team %d rank = %d, want %d", s.TeamID, s.Rank, i+1)
+		}
+	}
+	if ranked[3].GoalsDiff != 10 {
+		t.Errorf("rows lost their overall figures: %+v", ranked[3])
+	}
+}
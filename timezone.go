@@ -0,0 +1,124 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CountryTimezones maps league countries, as reported in FixtureData.LeagueCountry
+// and League.Country, to the timezone their fixtures are scheduled in. Countries
+// spanning several zones map to the zone of their capital or main football region.
+var CountryTimezones = map[string]string{
+	"England":      "Europe/London",
+	"Scotland":     "Europe/London",
+	"Wales":        "Europe/London",
+	"Spain":        "Europe/Madrid",
+	"Italy":        "Europe/Rome",
+	"Germany":      "Europe/Berlin",
+	"France":       "Europe/Paris",
+	"Netherlands":  "Europe/Amsterdam",
+	"Portugal":     "Europe/Lisbon",
+	"Belgium":      "Europe/Brussels",
+	"Turkey":       "Europe/Istanbul",
+	"Greece":       "Europe/Athens",
+	"Poland":       "Europe/Warsaw",
+	"Brazil":       "America/Sao_Paulo",
+	"Argentina":    "America/Argentina/Buenos_Aires",
+	"USA":          "America/New_York",
+	"Mexico":       "America/Mexico_City",
+	"Japan":        "Asia/Tokyo",
+	"Saudi-Arabia": "Asia/Riyadh",
+	"Australia":    "Australia/Sydney",
+	"World":        "UTC",
+}
+
+// ResolveLocation returns the location for an API-Football timezone name. An
+// empty name and "UTC" resolve to UTC; other names must be IANA zone names.
+func ResolveLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "UTC") || strings.EqualFold(name, "GMT") {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// LeagueLocation returns the location fixtures of a league in the given country
+// are scheduled in, falling back to UTC for unknown countries.
+func LeagueLocation(country string) *time.Location {
+	if name, ok := CountryTimezones[country]; ok {
+		if loc, err := ResolveLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// KickoffIn returns the kickoff time in the given location.
+func (f FixtureData) KickoffIn(loc *time.Location) time.Time {
+	return f.Date.In(loc)
+}
+
+// LocalKickoff returns the kickoff time in the fixture's own Timezone.
+func (f FixtureData) LocalKickoff() (time.Time, error) {
+	loc, err := ResolveLocation(f.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return f.KickoffIn(loc), nil
+}
+
+// LeagueKickoff returns the kickoff time in the timezone of the fixture's league country.
+func (f FixtureData) LeagueKickoff() time.Time {
+	return f.KickoffIn(LeagueLocation(f.LeagueCountry))
+}
+
+// MatchdayDate returns the calendar date, formatted YYYY-MM-DD, on which the
+// fixture kicks off in the given location.
+func (f FixtureData) MatchdayDate(loc *time.Location) string {
+	return f.KickoffIn(loc).Format(fixtureDateLayout)
+}
+
+// DayRange returns the [start, end) instants of a YYYY-MM-DD calendar day in
+// the given location. Days spanning a DST change are 23 or 25 hours long.
+func DayRange(date string, loc *time.Location) (time.Time, time.Time, error) {
+	d, err := time.ParseInLocation(fixtureDateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return d, d.AddDate(0, 0, 1), nil
+}
+
+// Location returns the location the request's date is interpreted in. An
+// empty Timezone is an InvalidRequest error rather than UTC; use
+// LeagueLocation to pick the zone of the league's country.
+func (r GetFixturesByDateAndLeagueRequest) Location() (*time.Location, error) {
+	if strings.TrimSpace(r.Timezone) == "" {
+		return nil, InvalidRequest(FieldError{Field: "timezone", Message: "is required"})
+	}
+	return ResolveLocation(r.Timezone)
+}
+
+// Range returns the [start, end) kickoff instants covered by the request's
+// date in its timezone.
+func (r GetFixturesByDateAndLeagueRequest) Range() (time.Time, time.Time, error) {
+	loc, err := r.Location()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return DayRange(r.Date, loc)
+}
+
+// Filter returns the FixtureFilter selecting the league's fixtures kicking off
+// on the requested date in the request's timezone.
+func (r GetFixturesByDateAndLeagueRequest) Filter() (FixtureFilter, error) {
+	from, to, err := r.Range()
+	if err != nil {
+		return FixtureFilter{}, err
+	}
+	return FixtureFilter{LeagueIDs: []string{r.League}, From: from, To: to}, nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetFixturesByDateAndLeagueRequestRange(t *testing.T) {
+	tests := []struct {
+		timezone string
+		date     string
+		from     time.Time
+		length   time.Duration
+	}{
+		{"UTC", "2024-10-27", time.Date(2024, 10, 27, 0, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"Europe/London", "2024-08-16", time.Date(2024, 8, 15, 23, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"Europe/London", "2024-10-27", time.Date(2024, 10, 26, 23, 0, 0, 0, time.UTC), 25 * time.Hour},
+		{"Europe/Madrid", "2024-03-31", time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC), 23 * time.Hour},
+	}
+	for _, tt := range tests {
+		r := GetFixturesByDateAndLeagueRequest{Date: tt.date, League: "39", Timezone: tt.timezone}
+		if err := r.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		from, to, err := r.Range()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !from.Equal(tt.from) || to.Sub(from) != tt.length {
+			t.Errorf("%s in %s = %v..%v, want %v for %v", tt.date, tt.timezone, from, to, tt.from, tt.length)
+		}
+	}
+}
+
+func TestGetFixturesByDateAndLeagueRequestRequiresTimezone(t *testing.T) {
+	for _, timezone := range []string{"", " ", "Mars/Olympus"} {
+		r := GetFixturesByDateAndLeagueRequest{Date: "2024-08-16", League: "39", Timezone: timezone}
+		if err := r.Validate(); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("Validate with timezone %q = %v, want an invalid request", timezone, err)
+		}
+	}
+	if _, err := (GetFixturesByDateAndLeagueRequest{Date: "2024-08-16", League: "39"}).Filter(); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("Filter without a timezone = %v, want an invalid request", err)
+	}
+}
+
+func TestLeagueKickoff(t *testing.T) {
+	f := FixtureData{Date: time.Date(2024, 8, 16, 23, 30, 0, 0, time.UTC), LeagueCountry: "Spain"}
+	if got := f.MatchdayDate(LeagueLocation(f.LeagueCountry)); got != "2024-08-17" {
+		t.Errorf("Spanish matchday = %s, want 2024-08-17", got)
+	}
+	if got := f.MatchdayDate(time.UTC); got != "2024-08-16" {
+		t.Errorf("UTC matchday = %s, want 2024-08-16", got)
+	}
+	if got := LeagueLocation("Atlantis"); got != time.UTC {
+		t.Errorf("unknown country location = %v, want UTC", got)
+	}
+}